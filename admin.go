@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// runMenuAdmin menjalankan mode admin untuk mengelola menu saat program berjalan.
+// Setiap perubahan langsung disimpan lewat repository dan dipakai di loop pemesanan.
+func runMenuAdmin(reader *bufio.Reader, menu MenuRepository) {
+	prompt := func(label string) string {
+		fmt.Print(label)
+		value, _ := reader.ReadString('\n')
+		return strings.TrimSpace(value)
+	}
+	readName := func() string {
+		return strings.ToLower(strings.Join(strings.Fields(prompt("Nama item: ")), " "))
+	}
+
+	for {
+		fmt.Println("\nMode admin menu")
+		fmt.Println("Perintah: tambah, ubah, hapus, daftar, kembali")
+		cmd := strings.ToLower(prompt("Admin: "))
+
+		var err error
+		switch cmd {
+		case "kembali", "":
+			return
+		case "daftar":
+			printMenu(os.Stdout, menu.List(), pricingFor(menu), "")
+			continue
+		case "tambah":
+			err = adminAddItem(menu, readName(), prompt("Harga: "), prompt("Kategori: "))
+		case "ubah":
+			err = adminUpdatePrice(menu, readName(), prompt("Harga baru: "))
+		case "hapus":
+			err = adminRemoveItem(menu, readName())
+		default:
+			err = fmt.Errorf("perintah admin '%s' tidak dikenal", cmd)
+		}
+
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		fmt.Println("Menu diperbarui")
+	}
+}
+
+// parsePrice mem-parsing harga yang dimasukkan admin
+func parsePrice(value string) (float64, error) {
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("harga tidak valid: %s", value)
+	}
+	return price, nil
+}
+
+// adminAddItem menambahkan item baru ke menu
+func adminAddItem(menu MenuRepository, name, priceStr, category string) error {
+	if existing, exists := lookupMenuItem(menu, name); exists {
+		if existing.Name != name {
+			return fmt.Errorf("'%s' sudah dipakai sebagai alias '%s'", name, existing.Name)
+		}
+		return fmt.Errorf("item '%s' sudah ada", name)
+	}
+	price, err := parsePrice(priceStr)
+	if err != nil {
+		return err
+	}
+	return menu.Add(MenuEntry{Name: name, Price: price, Category: strings.ToLower(category)})
+}
+
+// adminUpdatePrice mengubah harga item yang sudah ada
+func adminUpdatePrice(menu MenuRepository, name, priceStr string) error {
+	entry, exists := menu.Get(name)
+	if !exists {
+		return fmt.Errorf("Menu '%s' tidak tersedia", name)
+	}
+	price, err := parsePrice(priceStr)
+	if err != nil {
+		return err
+	}
+	entry.Price = price
+	return menu.Add(entry)
+}
+
+// adminRemoveItem menghapus item dari menu
+func adminRemoveItem(menu MenuRepository, name string) error {
+	if _, exists := menu.Get(name); !exists {
+		return fmt.Errorf("Menu '%s' tidak tersedia", name)
+	}
+	if len(menu.List()) == 1 {
+		return fmt.Errorf("menu tidak boleh kosong")
+	}
+	if bundles := bundlesContaining(menu.List(), name); len(bundles) > 0 {
+		return fmt.Errorf("item '%s' masih dipakai di paket %s", name, strings.Join(bundles, ", "))
+	}
+	return menu.Remove(name)
+}
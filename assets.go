@@ -0,0 +1,53 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// defaultMenu adalah menu bawaan yang ikut di dalam binary
+//
+//go:embed assets/menu.json
+var defaultMenu []byte
+
+// defaultReceiptTemplate adalah template struk bawaan
+//
+//go:embed assets/receipt.tmpl
+var defaultReceiptTemplate string
+
+// plainReceiptTemplate adalah template struk bawaan untuk mode -plain: setiap
+// baris satu kalimat dengan label eksplisit, sama seperti tampilan teks polos lain
+//
+//go:embed assets/receipt_plain.tmpl
+var plainReceiptTemplate string
+
+// loadReceiptTemplate membaca template struk dari path, atau template bawaan jika
+// path kosong. Template kustom dipakai apa adanya di kedua mode tampilan.
+func loadReceiptTemplate(path string, plain bool) (*template.Template, error) {
+	text := defaultReceiptTemplate
+	if plain {
+		text = plainReceiptTemplate
+	}
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca template struk: %w", err)
+		}
+		text = string(b)
+	}
+
+	funcs := template.FuncMap{
+		"money":    func(v float64) string { return currentLocale.FormatMoney(v) },
+		"taxLabel": describeTax,
+		// sentence membuang titik di akhir teks agar tidak dobel di akhir kalimat
+		"sentence": func(s string) string { return strings.TrimRight(s, ".") },
+	}
+	tmpl, err := template.New("receipt").Funcs(funcs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("template struk tidak valid: %w", err)
+	}
+	return tmpl, nil
+}
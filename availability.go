@@ -0,0 +1,157 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Clock sumber waktu yang bisa diganti, misalnya dengan jam tetap di unit test
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock memakai jam sistem
+type systemClock struct{}
+
+// Now mengembalikan waktu sistem saat ini
+func (systemClock) Now() time.Time { return time.Now() }
+
+// clock jam yang dipakai untuk jadwal ketersediaan menu
+var clock Clock = systemClock{}
+
+// AvailabilityWindow rentang jam item tersedia, misalnya {"from": "06:00", "until": "11:00"}.
+// Rentang yang melewati tengah malam (22:00-02:00) didukung.
+type AvailabilityWindow struct {
+	From  string `json:"from"`
+	Until string `json:"until"`
+}
+
+// parseClockTime mem-parsing "HH:MM" menjadi menit sejak tengah malam
+func parseClockTime(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("jam '%s' harus berformat HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// validateAvailability memeriksa format semua rentang ketersediaan
+func validateAvailability(windows []AvailabilityWindow) error {
+	for _, w := range windows {
+		from, err := parseClockTime(w.From)
+		if err != nil {
+			return err
+		}
+		until, err := parseClockTime(w.Until)
+		if err != nil {
+			return err
+		}
+		if from == until {
+			return fmt.Errorf("rentang %s-%s tidak boleh kosong", w.From, w.Until)
+		}
+	}
+	return nil
+}
+
+// contains mengecek apakah waktu t berada dalam rentang (from inklusif, until eksklusif)
+func (w AvailabilityWindow) contains(t time.Time) bool {
+	from, err := parseClockTime(w.From)
+	if err != nil {
+		return false
+	}
+	until, err := parseClockTime(w.Until)
+	if err != nil {
+		return false
+	}
+	now := t.Hour()*60 + t.Minute()
+	if from < until {
+		return now >= from && now < until
+	}
+	return now >= from || now < until
+}
+
+// seasonDateLayout format tanggal valid_from dan valid_until
+const seasonDateLayout = "2006-01-02"
+
+// validateSeason memeriksa format dan urutan tanggal masa berlaku
+func validateSeason(from, until string) error {
+	for _, d := range []string{from, until} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse(seasonDateLayout, d); err != nil {
+			return fmt.Errorf("tanggal '%s' harus berformat YYYY-MM-DD", d)
+		}
+	}
+	// Tanggal berformat YYYY-MM-DD bisa dibandingkan sebagai string
+	if from != "" && until != "" && from > until {
+		return fmt.Errorf("valid_from %s setelah valid_until %s", from, until)
+	}
+	return nil
+}
+
+// inSeason mengecek apakah tanggal t berada dalam masa berlaku item
+func (e MenuEntry) inSeason(t time.Time) bool {
+	today := t.Format(seasonDateLayout)
+	return (e.ValidFrom == "" || today >= e.ValidFrom) && (e.ValidUntil == "" || today <= e.ValidUntil)
+}
+
+// availableAt mengecek apakah item menu tersedia pada waktu t.
+// Item tanpa jadwal selalu tersedia selama masih dalam masa berlakunya.
+func (e MenuEntry) availableAt(t time.Time) bool {
+	if !e.inSeason(t) {
+		return false
+	}
+	if len(e.Available) == 0 {
+		return true
+	}
+	for _, w := range e.Available {
+		if w.contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeAvailability menggabungkan jadwal item menjadi teks, misalnya "06:00-11:00"
+func (e MenuEntry) describeAvailability() string {
+	s := ""
+	for i, w := range e.Available {
+		if i > 0 {
+			s += ", "
+		}
+		s += w.From + "-" + w.Until
+	}
+	return s
+}
+
+// availableMenu mengembalikan item menu yang tersedia pada waktu t
+func availableMenu(entries []MenuEntry, t time.Time) []MenuEntry {
+	result := make([]MenuEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.availableAt(t) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// checkAvailable mengembalikan error jika item menu tidak tersedia saat ini
+func checkAvailable(entry MenuEntry) error {
+	now := clock.Now()
+	if !entry.inSeason(now) {
+		return fmt.Errorf("Menu '%s' tidak sedang dijual (berlaku %s s.d. %s)", entry.Name, orDash(entry.ValidFrom), orDash(entry.ValidUntil))
+	}
+	if !entry.availableAt(now) {
+		return fmt.Errorf("Menu '%s' hanya tersedia pada jam %s", entry.Name, entry.describeAvailability())
+	}
+	return nil
+}
+
+// orDash mengganti teks kosong dengan "-"
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
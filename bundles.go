@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BundleComponent satu item penyusun paket, misalnya {"name": "es teh", "quantity": 1}
+type BundleComponent struct {
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+// isBundle mengecek apakah item menu adalah paket
+func (e MenuEntry) isBundle() bool {
+	return len(e.Bundle) > 0
+}
+
+// validateBundle memeriksa isi satu paket tanpa melihat item menu lain
+func validateBundle(components []BundleComponent) error {
+	seen := make(map[string]bool)
+	for _, c := range components {
+		if c.Name == "" {
+			return fmt.Errorf("nama item isi paket wajib diisi")
+		}
+		if c.Quantity <= 0 {
+			return fmt.Errorf("jumlah '%s' harus lebih dari nol", c.Name)
+		}
+		if seen[c.Name] {
+			return fmt.Errorf("item '%s' disebut lebih dari sekali", c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return nil
+}
+
+// validateBundleReferences memastikan setiap isi paket ada di menu dan bukan paket lain
+func validateBundleReferences(menu map[string]MenuEntry) error {
+	for _, entry := range menu {
+		for _, c := range entry.Bundle {
+			component, exists := menu[c.Name]
+			if !exists {
+				return fmt.Errorf("paket '%s': item '%s' tidak ada di menu", entry.Name, c.Name)
+			}
+			if component.isBundle() {
+				return fmt.Errorf("paket '%s': item '%s' juga sebuah paket", entry.Name, c.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// bundlesContaining mengembalikan nama paket yang berisi item tertentu
+func bundlesContaining(entries []MenuEntry, name string) []string {
+	var bundles []string
+	for _, entry := range entries {
+		for _, c := range entry.Bundle {
+			if c.Name == name {
+				bundles = append(bundles, entry.Name)
+			}
+		}
+	}
+	return bundles
+}
+
+// describeBundle memformat isi paket, misalnya "1x Nasi Goreng, 1x Es Teh"
+func describeBundle(components []BundleComponent) string {
+	parts := make([]string, len(components))
+	for i, c := range components {
+		parts[i] = fmt.Sprintf("%dx %s", c.Quantity, strings.Title(c.Name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// BundleContents isi paket pada baris pesanan, kosong jika bukan paket.
+// Dipakai juga oleh template struk.
+func (m *MenuItem) BundleContents() string {
+	return describeBundle(m.Components)
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"TUGAS_2MKTI/events"
+)
+
+// defaultCancelLogPath file catatan pembatalan jika -cancel-log tidak diisi
+const defaultCancelLogPath = "pembatalan.jsonl"
+
+// CancelReason kode alasan pembatalan pesanan
+type CancelReason string
+
+const (
+	ReasonCustomerLeft CancelReason = "pelanggan_pergi"
+	ReasonKitchenError CancelReason = "kesalahan_dapur"
+	ReasonWrongEntry   CancelReason = "salah_input"
+	ReasonDeclined     CancelReason = "ditolak_kasir"
+	ReasonOther        CancelReason = "lainnya"
+)
+
+// cancelReasons kode alasan yang bisa dipilih kasir beserta keterangannya, sesuai urutan tampil
+var cancelReasons = []struct {
+	Code        CancelReason
+	Description string
+}{
+	{ReasonCustomerLeft, "pelanggan pergi sebelum membayar"},
+	{ReasonKitchenError, "dapur tidak bisa membuat pesanan"},
+	{ReasonWrongEntry, "kasir salah memasukkan pesanan"},
+	{ReasonDeclined, "kasir menolak pesanan karena peringatan"},
+	{ReasonOther, "alasan lain"},
+}
+
+// parseCancelReason memvalidasi kode alasan pembatalan
+func parseCancelReason(code string) (CancelReason, error) {
+	for _, reason := range cancelReasons {
+		if string(reason.Code) == code {
+			return reason.Code, nil
+		}
+	}
+	return "", fmt.Errorf("alasan pembatalan '%s' tidak dikenal, ketik 'batal' untuk melihat daftar alasan", code)
+}
+
+// printCancelReasons menampilkan kode alasan pembatalan yang tersedia
+func printCancelReasons(w io.Writer) {
+	if plainOutput {
+		fmt.Fprintf(w, "\nAda %d alasan pembatalan. Ketik batal diikuti kode alasan.\n", len(cancelReasons))
+		for _, reason := range cancelReasons {
+			fmt.Fprintf(w, "Kode %s: %s.\n", reason.Code, reason.Description)
+		}
+		return
+	}
+
+	fmt.Fprintln(w, "\nAlasan pembatalan [batal <kode>]:")
+	for _, reason := range cancelReasons {
+		fmt.Fprintf(w, "- %s: %s\n", reason.Code, reason.Description)
+	}
+}
+
+// CancelLog catatan pembatalan pesanan, satu event order.cancelled per baris
+type CancelLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewCancelLog membuat catatan pembatalan di path
+func NewCancelLog(path string) *CancelLog {
+	return &CancelLog{path: path}
+}
+
+// Record menambahkan pembatalan pesanan ke akhir file
+func (l *CancelLog) Record(order *Order) error {
+	b, err := events.Encode(events.OrderCancelledV1{
+		OrderID:      order.ID,
+		TrackingCode: order.TrackingCode,
+		Reason:       string(order.CancelReason),
+		Items:        lineItems(order),
+		Total:        order.Total,
+	}, order.CancelledAt)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("gagal membuka catatan pembatalan: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("gagal menulis catatan pembatalan: %w", err)
+	}
+	return nil
+}
+
+// cancelOrder membatalkan pesanan dengan alasan tertentu, mengembalikan stok yang
+// sudah dipotong saat konfirmasi, lalu mencatat pembatalannya. Pesanan yang sudah
+// dikonfirmasi tetapi belum dibayar belum dikirim ke processor, sehingga kasir
+// boleh membatalkannya seperti draf. Pesanan yang sudah dibayar hanya boleh
+// dibatalkan role dengan izin void_after_fire.
+func cancelOrder(order *Order, reason CancelReason, menu MenuRepository, log *CancelLog) error {
+	if len(order.Items) == 0 {
+		return fmt.Errorf("pesanan kosong tidak perlu dibatalkan")
+	}
+	if order.Status == StatusPaid {
+		if err := requirePermission(PermVoidAfterFire); err != nil {
+			return err
+		}
+	}
+	committed := order.Status == StatusConfirmed || order.Status == StatusPaid
+	if err := order.Cancel(reason); err != nil {
+		return err
+	}
+	if committed {
+		if err := releaseStock(order, menu); err != nil {
+			return err
+		}
+	}
+	return log.Record(order)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// categoryOrder urutan tampil kategori yang umum; kategori lain tampil setelahnya secara alfabetis
+var categoryOrder = []string{"makanan", "minuman", "dessert"}
+
+// menuCategory satu kelompok item menu dalam kategori yang sama
+type menuCategory struct {
+	Name  string
+	Items []MenuEntry
+}
+
+// groupMenuByCategory mengelompokkan menu per kategori, diurutkan sesuai categoryOrder
+// lalu nama item. Jika filter diisi, hanya kategori tersebut yang dikembalikan.
+func groupMenuByCategory(entries []MenuEntry, filter string) []menuCategory {
+	groups := make(map[string][]MenuEntry)
+	for _, entry := range entries {
+		if filter != "" && entry.Category != filter {
+			continue
+		}
+		groups[entry.Category] = append(groups[entry.Category], entry)
+	}
+
+	rank := func(category string) int {
+		for i, c := range categoryOrder {
+			if c == category {
+				return i
+			}
+		}
+		return len(categoryOrder)
+	}
+
+	result := make([]menuCategory, 0, len(groups))
+	for name, items := range groups {
+		sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+		result = append(result, menuCategory{Name: name, Items: items})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		ri, rj := rank(result[i].Name), rank(result[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// menuCategoryFilter kategori yang sedang ditampilkan di loop pemesanan, kosong berarti semua
+var menuCategoryFilter string
+
+// runCategoryCommand menjalankan perintah "kategori": tanpa argumen menampilkan daftar
+// kategori, "kategori <nama>" memfilter menu, "kategori semua" menghapus filter
+func runCategoryCommand(input string, menu MenuRepository) error {
+	arg := strings.TrimSpace(strings.TrimPrefix(input, "kategori"))
+	switch arg {
+	case "":
+		fmt.Println("\nKategori:")
+		for _, group := range groupMenuByCategory(menu.List(), "") {
+			fmt.Printf("- %s (%d item)\n", strings.Title(group.Name), len(group.Items))
+		}
+		return nil
+	case "semua":
+		menuCategoryFilter = ""
+		return nil
+	}
+
+	for _, entry := range menu.List() {
+		if entry.Category == arg {
+			menuCategoryFilter = arg
+			return nil
+		}
+	}
+	return fmt.Errorf("kategori '%s' tidak ada", arg)
+}
+
+// addMenuEntry menambahkan item dari menu ke pesanan beserta kategori dan modifiernya.
+// Harga dikunci saat item ditambahkan, setelah aturan harga yang berlaku saat itu.
+func (o *Order) addMenuEntry(menu MenuRepository, entry MenuEntry, quantity float64, seat int, mods ...SelectedModifier) {
+	price, _ := pricingFor(menu).price(entry, clock.Now())
+	item := o.AddItemForSeat(strings.Title(entry.Name), price, quantity, seat)
+	item.Category = entry.Category
+	item.Modifiers = mods
+	item.Components = entry.Bundle
+	item.TaxClass = entry.taxClass()
+	item.Unit = entry.Unit
+	item.Description = entry.Description
+	item.SKU = entry.SKU
+	item.ImageURL = entry.ImageURL
+	o.calculateTotal()
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// defaultConfigPath file konfigurasi yang dibaca sebelum flag command line.
+// Isinya pasangan nama flag dan nilai, misalnya {"store": "warung-budi", "locale": "id-ID"}.
+const defaultConfigPath = "pos.json"
+
+// Config menyimpan konfigurasi program dari file konfigurasi dan flag command line
+type Config struct {
+	StoreID                 string
+	MenuPath                string
+	ReceiptTemplatePath     string
+	LocaleCode              string
+	LocalePath              string
+	PricingEngine           string
+	FullService             bool
+	QueueFile               string
+	CustomFieldsPath        string
+	Plain                   bool
+	HooksPath               string
+	FlagsPath               string
+	DisplayTarget           string
+	Role                    string
+	RolesPath               string
+	WifiVoucherPath         string
+	PricingRulesPath        string
+	TaxRatesPath            string
+	LowStockWebhook         string
+	ServeAddr               string
+	MenuURL                 string
+	MenuRefresh             time.Duration
+	PageSize                int
+	Training                bool
+	HeldOrdersPath          string
+	CancelLogPath           string
+	DiscountCap             float64
+	DiscountApprovalPercent float64
+}
+
+// parseConfig membaca konfigurasi dari argumen command line
+func parseConfig(args []string) (*Config, error) {
+	cfg := &Config{}
+	fs := flag.NewFlagSet("pos", flag.ContinueOnError)
+	fs.StringVar(&cfg.StoreID, "store", "toko-1", "kode toko, dipakai untuk feature flag per toko")
+	fs.StringVar(&cfg.MenuPath, "menu", "", "path file menu JSON (default: menu.json jika ada, atau menu bawaan)")
+	fs.StringVar(&cfg.ReceiptTemplatePath, "receipt-template", "", "path template struk (default: template bawaan)")
+	fs.StringVar(&cfg.LocaleCode, "locale", "id-ID", "kode locale bawaan (id-ID, en-US, ms-MY)")
+	fs.StringVar(&cfg.LocalePath, "locale-file", "", "path file locale JSON, menggantikan -locale")
+	fs.StringVar(&cfg.PricingEngine, "pricing", "default", "nama pricing engine yang dipakai")
+	fs.BoolVar(&cfg.FullService, "full-service", false, "mode layanan penuh: catat nomor kursi per item")
+	fs.StringVar(&cfg.QueueFile, "queue-file", "", "path file nomor antrian; jika diisi, slip nomor antrian dicetak")
+	fs.StringVar(&cfg.CustomFieldsPath, "fields", "", "path file JSON definisi field tambahan pesanan")
+	fs.BoolVar(&cfg.Plain, "plain", false, "tampilan teks polos untuk pembaca layar")
+	fs.StringVar(&cfg.HooksPath, "hooks", "", "path file JSON berisi perintah shell per titik hook")
+	fs.StringVar(&cfg.FlagsPath, "flags", "", "path file JSON feature flag")
+	fs.StringVar(&cfg.DisplayTarget, "display", "", "layar pelanggan: terminal, tcp://host:port, atau path perangkat (misalnya /dev/rfcomm0)")
+	fs.StringVar(&cfg.Role, "role", "kasir", "role operator yang sedang bertugas")
+	fs.StringVar(&cfg.RolesPath, "roles", "", "path file JSON matriks role dan permission")
+	fs.StringVar(&cfg.WifiVoucherPath, "wifi-vouchers", "", "path file JSON pool voucher Wi-Fi tamu; jika diisi, voucher dicetak di struk")
+	fs.StringVar(&cfg.PricingRulesPath, "pricing-rules", "", "path file JSON aturan harga berbasis waktu (misalnya happy hour)")
+	fs.StringVar(&cfg.TaxRatesPath, "tax-rates", "", "path file JSON tarif pajak (persen) per kelas pajak")
+	fs.StringVar(&cfg.LowStockWebhook, "low-stock-webhook", "", "URL webhook/bot untuk notifikasi stok menipis ke manajer")
+	fs.StringVar(&cfg.ServeAddr, "addr", "localhost:8080", "alamat server HTTP untuk perintah serve")
+	fs.StringVar(&cfg.MenuURL, "menu-url", "", "URL menu pusat (HTTP/HTTPS); disimpan ke file -menu sebagai cache")
+	fs.DurationVar(&cfg.MenuRefresh, "menu-refresh", 5*time.Minute, "jeda pembaruan menu dari -menu-url, 0 untuk hanya mengambil saat program mulai")
+	fs.IntVar(&cfg.PageSize, "page-size", 10, "jumlah item menu per halaman di loop pemesanan, 0 untuk tanpa halaman")
+	fs.BoolVar(&cfg.Training, "latihan", false, "mode latihan kasir baru: data terpisah, struk bertanda LATIHAN, tanpa hook dan voucher")
+	fs.StringVar(&cfg.HeldOrdersPath, "held-orders", defaultHeldOrdersPath, "path file JSON pesanan yang ditahan")
+	fs.StringVar(&cfg.CancelLogPath, "cancel-log", defaultCancelLogPath, "path file catatan pembatalan pesanan (satu event JSON per baris)")
+	fs.Float64Var(&cfg.DiscountCap, "discount-cap", 0, "batas potongan per pesanan dalam mata uang, 0 untuk tanpa batas")
+	fs.Float64Var(&cfg.DiscountApprovalPercent, "discount-approval", 10, "potongan di atas persen subtotal ini memerlukan izin apply_discount_over_x")
+	if err := applyConfigFile(fs, defaultConfigPath); err != nil {
+		return nil, err
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if cfg.MenuRefresh < 0 {
+		return nil, fmt.Errorf("-menu-refresh tidak boleh negatif: %s", cfg.MenuRefresh)
+	}
+	return cfg, nil
+}
+
+// applyConfigFile mengisi nilai flag dari file konfigurasi jika ada.
+// Flag yang diberikan di command line tetap menimpa nilai dari file.
+func applyConfigFile(fs *flag.FlagSet, path string) error {
+	b, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("gagal membaca konfigurasi: %w", err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal(b, &values); err != nil {
+		return fmt.Errorf("format konfigurasi %s tidak valid: %w", path, err)
+	}
+	for name, value := range values {
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("konfigurasi %s: opsi '%s' tidak dikenal", path, name)
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("konfigurasi %s: opsi '%s': %w", path, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Tipe data yang didukung untuk field tambahan
+const (
+	fieldTypeText    = "text"
+	fieldTypeInteger = "integer"
+	fieldTypeNumber  = "number"
+)
+
+// CustomFieldDef definisi field tambahan pesanan dari file konfigurasi
+type CustomFieldDef struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	Pattern  string `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// CustomFieldValue nilai field tambahan yang diisi pada pesanan
+type CustomFieldValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// loadCustomFields membaca definisi field tambahan dari file JSON
+func loadCustomFields(path string) ([]*CustomFieldDef, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca field tambahan: %w", err)
+	}
+
+	var defs []*CustomFieldDef
+	if err := json.Unmarshal(b, &defs); err != nil {
+		return nil, fmt.Errorf("format field tambahan tidak valid: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i, def := range defs {
+		if strings.TrimSpace(def.Name) == "" {
+			return nil, fmt.Errorf("field tambahan ke-%d tidak memiliki nama", i+1)
+		}
+		if seen[def.Name] {
+			return nil, fmt.Errorf("field tambahan '%s' didefinisikan lebih dari sekali", def.Name)
+		}
+		seen[def.Name] = true
+
+		switch def.Type {
+		case "":
+			def.Type = fieldTypeText
+		case fieldTypeText, fieldTypeInteger, fieldTypeNumber:
+		default:
+			return nil, fmt.Errorf("tipe field '%s' tidak didukung: %s", def.Name, def.Type)
+		}
+
+		if def.Pattern != "" {
+			def.pattern, err = regexp.Compile(def.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("pola field '%s' tidak valid: %w", def.Name, err)
+			}
+		}
+	}
+	return defs, nil
+}
+
+// Validate memeriksa nilai terhadap tipe, keharusan dan pola field
+func (d *CustomFieldDef) Validate(value string) error {
+	if value == "" {
+		if d.Required {
+			return fmt.Errorf("%s wajib diisi", d.Name)
+		}
+		return nil
+	}
+
+	switch d.Type {
+	case fieldTypeInteger:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%s harus berupa bilangan bulat", d.Name)
+		}
+	case fieldTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s harus berupa angka", d.Name)
+		}
+	}
+
+	if d.pattern != nil && !d.pattern.MatchString(value) {
+		return fmt.Errorf("format %s tidak valid", d.Name)
+	}
+	return nil
+}
+
+// customField mengambil nilai field tambahan pesanan berdasarkan nama
+func (o *Order) customField(name string) (string, bool) {
+	for _, field := range o.CustomFields {
+		if field.Name == name {
+			return field.Value, true
+		}
+	}
+	return "", false
+}
+
+// setCustomField mengisi field tambahan pesanan, menggantikan nilai sebelumnya
+// dengan nama yang sama. Nilai kosong menghapus field.
+func (o *Order) setCustomField(name, value string) {
+	fields := o.CustomFields[:0]
+	for _, field := range o.CustomFields {
+		if field.Name != name {
+			fields = append(fields, field)
+		}
+	}
+	if value != "" {
+		fields = append(fields, CustomFieldValue{Name: name, Value: value})
+	}
+	o.CustomFields = fields
+}
+
+// promptCustomFields menanyakan setiap field tambahan sampai nilainya valid. Pesanan
+// yang ditanya ulang, misalnya setelah dilanjutkan, tidak mendapat field ganda.
+func promptCustomFields(reader *bufio.Reader, defs []*CustomFieldDef, order *Order) error {
+	for _, def := range defs {
+		for {
+			label := def.Name
+			if !def.Required {
+				label += " (opsional)"
+			}
+			fmt.Printf("%s: ", label)
+			value, err := readLine(reader)
+			if err != nil {
+				return err
+			}
+
+			if err := def.Validate(value); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			order.setCustomField(def.Name, value)
+			break
+		}
+	}
+	return nil
+}
+
+// inputEndedMessage pesan saat sesi ditutup karena input kasir habis
+const inputEndedMessage = "\nInput berakhir, sesi kasir ditutup"
+
+// readLine membaca satu baris input tanpa spasi di awal dan akhir. Input yang
+// habis (EOF) dikembalikan sebagai io.EOF agar prompt yang mengulang sampai valid
+// tidak berputar tanpa akhir dan pemanggil bisa menutup sesi dengan rapi.
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		if errors.Is(err, io.EOF) {
+			return "", io.EOF
+		}
+		return "", fmt.Errorf("gagal membaca input: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DiscountKind jenis potongan harga pesanan
+type DiscountKind string
+
+const (
+	DiscountPercent DiscountKind = "persen"
+	DiscountFixed   DiscountKind = "nominal"
+)
+
+// OrderDiscount potongan harga untuk seluruh pesanan. Potongan dihitung dari
+// subtotal sebelum pajak, sehingga pajak dihitung dari harga setelah potongan.
+type OrderDiscount struct {
+	Kind   DiscountKind `json:"kind"`
+	Value  float64      `json:"value"`  // persen atau nominal sesuai Kind
+	Amount float64      `json:"amount"` // potongan yang dipakai setelah dibatasi
+	// Approved potongan di atas discountApprovalPercent sudah disetujui role dengan izin apply_discount_over_x
+	Approved bool `json:"approved,omitempty"`
+}
+
+var (
+	// discountCap batas potongan per pesanan dalam mata uang, 0 berarti tanpa batas
+	discountCap float64
+	// discountApprovalPercent potongan di atas persentase subtotal ini memerlukan izin apply_discount_over_x
+	discountApprovalPercent = 10.0
+)
+
+// requestedAmount potongan yang diminta untuk subtotal tertentu, paling banyak
+// sebesar subtotal dan discountCap
+func (d *OrderDiscount) requestedAmount(subtotal float64) float64 {
+	amount := d.Value
+	if d.Kind == DiscountPercent {
+		amount = subtotal * d.Value / 100
+	}
+	if discountCap > 0 {
+		amount = math.Min(amount, discountCap)
+	}
+	return math.Min(amount, subtotal)
+}
+
+// amountFor potongan yang dipakai untuk subtotal tertentu. Potongan yang belum
+// disetujui dibatasi discountApprovalPercent dari subtotal setiap kali dihitung
+// ulang, misalnya saat item dihapus setelah diskon nominal diberikan.
+func (d *OrderDiscount) amountFor(subtotal float64) float64 {
+	amount := d.requestedAmount(subtotal)
+	if !d.Approved {
+		amount = math.Min(amount, subtotal*discountApprovalPercent/100)
+	}
+	return currentLocale.Round(amount)
+}
+
+// Label label potongan untuk struk, misalnya "Diskon 10%"
+func (d *OrderDiscount) Label() string {
+	if d.Kind == DiscountPercent {
+		return fmt.Sprintf("Diskon %g%%", d.Value)
+	}
+	return "Diskon"
+}
+
+// ApplyDiscount memberi potongan persen atau nominal untuk seluruh pesanan, menggantikan
+// potongan sebelumnya. Potongan di atas discountApprovalPercent dari subtotal
+// memerlukan izin apply_discount_over_x; tanpa izin itu batasnya tetap berlaku
+// walaupun subtotal berubah.
+func (o *Order) ApplyDiscount(kind DiscountKind, value float64) error {
+	if err := o.requireDraft(); err != nil {
+		return err
+	}
+	switch kind {
+	case DiscountPercent:
+		if value <= 0 || value > 100 {
+			return fmt.Errorf("persen diskon harus antara 0 dan 100")
+		}
+	case DiscountFixed:
+		if value <= 0 {
+			return fmt.Errorf("nominal diskon harus lebih dari nol")
+		}
+	default:
+		return fmt.Errorf("jenis diskon '%s' tidak dikenal", kind)
+	}
+
+	if len(o.Items) == 0 {
+		return fmt.Errorf("pesanan masih kosong, tambahkan item sebelum memberi diskon")
+	}
+
+	discount := &OrderDiscount{Kind: kind, Value: value}
+	subtotal := pricingEngineFor(o).Price(o).Total
+	if discount.requestedAmount(subtotal) > subtotal*discountApprovalPercent/100 {
+		if err := requirePermission(PermApplyDiscountOverX); err != nil {
+			return fmt.Errorf("diskon di atas %g%%: %w", discountApprovalPercent, err)
+		}
+		discount.Approved = true
+	}
+	o.Discount = discount
+	o.calculateTotal()
+	return nil
+}
+
+// RemoveDiscount menghapus potongan pesanan
+func (o *Order) RemoveDiscount() error {
+	if err := o.requireDraft(); err != nil {
+		return err
+	}
+	o.Discount = nil
+	o.calculateTotal()
+	return nil
+}
+
+// runDiscountCommand menjalankan "diskon 10%", "diskon 5000", atau "diskon hapus"
+func runDiscountCommand(input string, order *Order) error {
+	arg := strings.TrimSpace(strings.TrimPrefix(input, "diskon"))
+	switch {
+	case arg == "":
+		return fmt.Errorf("format: diskon <persen>%% | diskon <nominal> | diskon hapus")
+	case arg == "hapus":
+		return order.RemoveDiscount()
+	case strings.HasSuffix(arg, "%"):
+		value, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(arg, "%")), 64)
+		if err != nil {
+			return fmt.Errorf("persen diskon tidak valid: %s", arg)
+		}
+		return order.ApplyDiscount(DiscountPercent, value)
+	}
+	value, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Errorf("nominal diskon tidak valid: %s", arg)
+	}
+	return order.ApplyDiscount(DiscountFixed, value)
+}
+
+// discountAmount potongan yang dipakai pesanan, 0 jika tidak ada
+func discountAmount(order *Order) float64 {
+	if order.Discount == nil {
+		return 0
+	}
+	return order.Discount.Amount
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// withRole memasang role operator untuk satu test lalu mengembalikan role sebelumnya
+func withRole(tb testing.TB, role string) {
+	tb.Helper()
+	previous := currentRole
+	if err := setRole(role); err != nil {
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { currentRole = previous })
+}
+
+// withTaxRates memasang tarif pajak untuk satu test
+func withTaxRates(tb testing.TB, rates map[string]float64) {
+	tb.Helper()
+	previous := taxRates
+	taxRates = rates
+	tb.Cleanup(func() { taxRates = previous })
+}
+
+func TestApplyDiscount(t *testing.T) {
+	withRole(t, "kasir")
+	tests := []struct {
+		name       string
+		kind       DiscountKind
+		value      float64
+		cap        float64
+		wantAmount float64
+		wantErr    string
+	}{
+		{name: "persen", kind: DiscountPercent, value: 10, wantAmount: 9500},
+		{name: "nominal", kind: DiscountFixed, value: 5000, wantAmount: 5000},
+		{name: "dibatasi discountCap", kind: DiscountPercent, value: 10, cap: 4000, wantAmount: 4000},
+		{name: "persen di atas batas persetujuan", kind: DiscountPercent, value: 20, wantErr: "apply_discount_over_x"},
+		{name: "nominal di atas batas persetujuan", kind: DiscountFixed, value: 20000, wantErr: "apply_discount_over_x"},
+		{name: "persen nol", kind: DiscountPercent, value: 0, wantErr: "antara 0 dan 100"},
+		{name: "persen lebih dari 100", kind: DiscountPercent, value: 120, wantErr: "antara 0 dan 100"},
+		{name: "nominal negatif", kind: DiscountFixed, value: -1, wantErr: "lebih dari nol"},
+		{name: "jenis tidak dikenal", kind: "voucher", value: 1, wantErr: "tidak dikenal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func(c float64) { discountCap = c }(discountCap)
+			discountCap = tt.cap
+
+			order := newTestOrder()
+			err := order.ApplyDiscount(tt.kind, tt.value)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, ingin mengandung %q", err, tt.wantErr)
+				}
+				if order.Discount != nil || order.Total != 95000 {
+					t.Errorf("diskon tetap dipasang setelah error, total %v", order.Total)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if order.Discount.Amount != tt.wantAmount || order.Total != 95000-tt.wantAmount {
+				t.Errorf("potongan %v total %v, ingin potongan %v", order.Discount.Amount, order.Total, tt.wantAmount)
+			}
+		})
+	}
+}
+
+func TestDiscountApprovalHoldsAfterRecompute(t *testing.T) {
+	withRole(t, "kasir")
+
+	// Diskon nominal 9.000 dari 95.000 masih di bawah 10%, lalu item dihapus
+	order := newTestOrder()
+	if err := order.ApplyDiscount(DiscountFixed, 9000); err != nil {
+		t.Fatal(err)
+	}
+	if err := order.RemoveItem(0); err != nil {
+		t.Fatal(err)
+	}
+	// Subtotal 45.000: tanpa izin potongan tetap paling banyak 10%
+	if order.Discount.Amount != 4500 || order.Total != 40500 {
+		t.Errorf("potongan %v total %v, ingin potongan 4500 total 40500", order.Discount.Amount, order.Total)
+	}
+
+	// Diskon yang disetujui manajer tetap utuh walaupun subtotal turun
+	withRole(t, "manajer")
+	approved := newTestOrder()
+	if err := approved.ApplyDiscount(DiscountFixed, 20000); err != nil {
+		t.Fatal(err)
+	}
+	withRole(t, "kasir")
+	if err := approved.RemoveItem(0); err != nil {
+		t.Fatal(err)
+	}
+	if approved.Discount.Amount != 20000 || approved.Total != 25000 {
+		t.Errorf("potongan %v total %v, ingin potongan 20000 total 25000", approved.Discount.Amount, approved.Total)
+	}
+}
+
+func TestDiscountOnEmptyOrder(t *testing.T) {
+	withRole(t, "manajer")
+	for _, kind := range []DiscountKind{DiscountFixed, DiscountPercent} {
+		if err := NewOrder().ApplyDiscount(kind, 10); err == nil || !strings.Contains(err.Error(), "kosong") {
+			t.Errorf("diskon %s pada pesanan kosong: error = %v", kind, err)
+		}
+	}
+}
+
+func TestDiscountReducesTaxBase(t *testing.T) {
+	withRole(t, "manajer")
+	withTaxRates(t, map[string]float64{defaultTaxClass: 10})
+
+	tests := []struct {
+		name      string
+		kind      DiscountKind
+		value     float64
+		wantTax   float64
+		wantTotal float64
+	}{
+		{"tanpa diskon", "", 0, 9500, 104500},
+		{"diskon 20%", DiscountPercent, 20, 7600, 83600},
+		{"diskon nominal", DiscountFixed, 45000, 5000, 55000},
+		{"diskon penuh", DiscountPercent, 100, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newTestOrder()
+			if tt.kind != "" {
+				if err := order.ApplyDiscount(tt.kind, tt.value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			tax := 0.0
+			for _, line := range order.Taxes {
+				tax += line.Amount
+			}
+			if tax != tt.wantTax || order.Total != tt.wantTotal {
+				t.Errorf("pajak %v total %v, ingin pajak %v total %v", tax, order.Total, tt.wantTax, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestRunDiscountCommand(t *testing.T) {
+	withRole(t, "kasir")
+	tests := []struct {
+		input      string
+		wantAmount float64
+		wantErr    bool
+	}{
+		{"diskon 5%", 4750, false},
+		{"diskon 2500", 2500, false},
+		{"diskon", 0, true},
+		{"diskon lima%", 0, true},
+		{"diskon abc", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			order := newTestOrder()
+			err := runDiscountCommand(tt.input, order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, ingin error %v", err, tt.wantErr)
+			}
+			if discountAmount(order) != tt.wantAmount {
+				t.Errorf("potongan = %v, ingin %v", discountAmount(order), tt.wantAmount)
+			}
+		})
+	}
+
+	order := newTestOrder()
+	runDiscountCommand("diskon 5%", order)
+	if err := runDiscountCommand("diskon hapus", order); err != nil || order.Discount != nil || order.Total != 95000 {
+		t.Errorf("diskon hapus: error %v, total %v", err, order.Total)
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Display layar pelanggan yang menampilkan item terakhir, total dan kembalian
+type Display interface {
+	ShowLine(item *MenuItem, total float64) error
+	ShowTotal(total float64) error
+	ShowChange(payment, change float64) error
+	Close() error
+}
+
+// displayDialTimeout batas waktu koneksi ke layar jaringan
+const displayDialTimeout = 3 * time.Second
+
+// displayWriteTimeout batas waktu mengirim satu pesan ke layar, agar layar yang
+// macet tidak menghentikan kasir
+const displayWriteTimeout = 2 * time.Second
+
+// openDisplay membuka layar pelanggan berdasarkan target:
+// kosong (tanpa layar), "terminal", "tcp://host:port" untuk layar jaringan
+// atau layar Android, dan path perangkat (misalnya /dev/rfcomm0 untuk Bluetooth
+// atau port serial pole display)
+func openDisplay(target string) (Display, error) {
+	switch {
+	case target == "":
+		return noDisplay{}, nil
+	case target == "terminal":
+		return &terminalDisplay{w: os.Stdout}, nil
+	case strings.HasPrefix(target, "tcp://"):
+		conn, err := net.DialTimeout("tcp", strings.TrimPrefix(target, "tcp://"), displayDialTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("gagal terhubung ke layar pelanggan: %w", err)
+		}
+		return &protocolDisplay{w: conn, timeout: displayWriteTimeout}, nil
+	default:
+		f, err := os.OpenFile(target, os.O_WRONLY, 0)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membuka layar pelanggan: %w", err)
+		}
+		return &protocolDisplay{w: f, timeout: displayWriteTimeout}, nil
+	}
+}
+
+// noDisplay dipakai saat tidak ada layar pelanggan
+type noDisplay struct{}
+
+func (noDisplay) ShowLine(*MenuItem, float64) error { return nil }
+func (noDisplay) ShowTotal(float64) error           { return nil }
+func (noDisplay) ShowChange(float64, float64) error { return nil }
+func (noDisplay) Close() error                      { return nil }
+
+// terminalDisplay menampilkan layar pelanggan di terminal kasir
+type terminalDisplay struct {
+	w io.Writer
+}
+
+func (d *terminalDisplay) ShowLine(item *MenuItem, total float64) error {
+	return printDisplayLine(d.w, item, total)
+}
+
+func (d *terminalDisplay) ShowTotal(total float64) error {
+	return printDisplayTotal(d.w, total)
+}
+
+func (d *terminalDisplay) ShowChange(payment, change float64) error {
+	return printDisplayChange(d.w, payment, change)
+}
+
+func (d *terminalDisplay) Close() error { return nil }
+
+// protocolDisplay mengirim protokol teks sederhana ke layar jaringan atau perangkat.
+// Setiap pesan satu baris, field dipisah tab:
+//
+//	LINE   <nama> <jumlah> <subtotal baris> <total>
+//	TOTAL  <total>
+//	CHANGE <bayar> <kembalian>
+type protocolDisplay struct {
+	w       io.WriteCloser
+	timeout time.Duration // batas waktu per pesan, 0 berarti tanpa batas
+}
+
+// deadlineWriter writer yang mendukung batas waktu tulis, misalnya net.Conn
+type deadlineWriter interface {
+	SetWriteDeadline(t time.Time) error
+}
+
+func (d *protocolDisplay) send(fields ...string) error {
+	// Perangkat yang tidak mendukung deadline (misalnya file biasa) tetap ditulis tanpa batas
+	if dw, ok := d.w.(deadlineWriter); ok && d.timeout > 0 {
+		dw.SetWriteDeadline(time.Now().Add(d.timeout))
+	}
+	_, err := io.WriteString(d.w, strings.Join(fields, "\t")+"\n")
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return fmt.Errorf("layar tidak merespons dalam %s", d.timeout)
+	}
+	return err
+}
+
+func (d *protocolDisplay) ShowLine(item *MenuItem, total float64) error {
+	return d.send("LINE", item.Name, strconv.FormatFloat(item.Quantity, 'f', -1, 64),
+		currentLocale.FormatMoney(item.LineTotal()), currentLocale.FormatMoney(total))
+}
+
+func (d *protocolDisplay) ShowTotal(total float64) error {
+	return d.send("TOTAL", currentLocale.FormatMoney(total))
+}
+
+func (d *protocolDisplay) ShowChange(payment, change float64) error {
+	return d.send("CHANGE", currentLocale.FormatMoney(payment), currentLocale.FormatMoney(change))
+}
+
+func (d *protocolDisplay) Close() error {
+	return d.w.Close()
+}
+
+// reportDisplayError menampilkan error layar pelanggan tanpa menghentikan transaksi
+func reportDisplayError(err error) {
+	if err != nil {
+		fmt.Printf("Peringatan: layar pelanggan: %v\n", err)
+	}
+}
+
+// showLastLine menampilkan item yang terakhir ditambahkan ke pesanan
+func showLastLine(d Display, order *Order) {
+	if len(order.Items) == 0 {
+		return
+	}
+	reportDisplayError(d.ShowLine(order.Items[len(order.Items)-1], order.Total))
+}
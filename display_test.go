@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProtocolDisplaySend(t *testing.T) {
+	client, server := net.Pipe()
+	defer server.Close()
+	d := &protocolDisplay{w: client, timeout: time.Second}
+	defer d.Close()
+
+	lines := make(chan string, 1)
+	go func() {
+		line, _ := bufio.NewReader(server).ReadString('\n')
+		lines <- line
+	}()
+	if err := d.ShowTotal(55000); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := <-lines, "TOTAL\tRp55.000\n"; got != want {
+		t.Errorf("pesan = %q, ingin %q", got, want)
+	}
+}
+
+func TestProtocolDisplayWriteTimeout(t *testing.T) {
+	// Tidak ada yang membaca dari sisi layar, sehingga penulisan tertahan
+	client, server := net.Pipe()
+	defer server.Close()
+	d := &protocolDisplay{w: client, timeout: 20 * time.Millisecond}
+	defer d.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- d.ShowChange(100000, 45000) }()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "tidak merespons") {
+			t.Errorf("error = %v, ingin timeout layar", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("penulisan ke layar tidak dibatasi waktu")
+	}
+}
+
+func TestTerminalDisplayPlain(t *testing.T) {
+	defer func(plain bool) { plainOutput = plain }(plainOutput)
+	item := &MenuItem{Name: "Nasi Goreng", Price: 25000, Quantity: 2}
+
+	tests := []struct {
+		plain bool
+		want  string
+	}{
+		{false, "[Layar pelanggan] Nasi Goreng x2 | Total Rp50.000\n[Layar pelanggan] Bayar Rp100.000 | Kembali Rp50.000\n"},
+		{true, "Layar pelanggan: Nasi Goreng, jumlah 2, total Rp50.000.\nLayar pelanggan: dibayar Rp100.000, kembalian Rp50.000.\n"},
+	}
+	for _, tt := range tests {
+		plainOutput = tt.plain
+		var b strings.Builder
+		d := &terminalDisplay{w: &b}
+		if err := d.ShowLine(item, 50000); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.ShowChange(100000, 50000); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("plain %v: tampilan = %q, ingin %q", tt.plain, b.String(), tt.want)
+		}
+	}
+}
+
+func TestPlainReceiptTemplate(t *testing.T) {
+	tmpl, err := loadReceiptTemplate("", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := newTestOrder()
+	order.Items[0].Note = "tanpa bawang."
+	order.Payment, order.Change = 100000, 5000
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, order); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Kode pesanan: " + order.TrackingCode + ".", "Catatan Nasi Goreng: tanpa bawang.\n", "Kembalian: Rp5.000."} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("struk tidak mengandung %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// doctorCheck satu pemeriksaan pada perintah "doctor"
+type doctorCheck struct {
+	Name string
+	Run  func(cfg *Config) error
+}
+
+// doctorChecks berisi semua pemeriksaan yang dijalankan "doctor", sesuai urutan
+var doctorChecks = []doctorCheck{
+	{Name: "pricing engine", Run: func(cfg *Config) error {
+		return SetPricingEngine(cfg.PricingEngine)
+	}},
+	{Name: "locale", Run: func(cfg *Config) error {
+		_, err := loadLocale(cfg.LocaleCode, cfg.LocalePath)
+		return err
+	}},
+	{Name: "menu", Run: func(cfg *Config) error {
+		_, err := loadMenu(resolveMenuPath(cfg.MenuPath))
+		return err
+	}},
+	{Name: "menu pusat", Run: func(cfg *Config) error {
+		if cfg.MenuURL == "" {
+			return nil
+		}
+		// Tanpa path cache, permintaan selalu mengambil dan memvalidasi menu lengkap
+		_, err := NewRemoteMenu(cfg.MenuURL, "").fetch()
+		return err
+	}},
+	{Name: "aturan harga", Run: func(cfg *Config) error {
+		return loadPricingRules(cfg.PricingRulesPath)
+	}},
+	{Name: "tarif pajak", Run: func(cfg *Config) error {
+		rates, err := loadTaxRates(cfg.TaxRatesPath)
+		if err != nil {
+			return err
+		}
+		menu, err := loadMenu(resolveMenuPath(cfg.MenuPath))
+		if err != nil {
+			return nil // sudah dilaporkan oleh pemeriksaan menu
+		}
+		return validateTaxClasses(NewInMemoryMenuRepository(menu).List(), rates)
+	}},
+	{Name: "template struk", Run: func(cfg *Config) error {
+		tmpl, err := loadReceiptTemplate(cfg.ReceiptTemplatePath, cfg.Plain)
+		if err != nil {
+			return err
+		}
+		sample := NewOrder()
+		sample.AddItem("Contoh", 1000, 1)
+		return tmpl.Execute(io.Discard, sample)
+	}},
+	{Name: "field tambahan", Run: func(cfg *Config) error {
+		_, err := loadCustomFields(cfg.CustomFieldsPath)
+		return err
+	}},
+	{Name: "hooks", Run: func(cfg *Config) error {
+		return loadHooks(cfg.HooksPath)
+	}},
+	{Name: "feature flag", Run: func(cfg *Config) error {
+		return loadFeatureFlags(cfg.FlagsPath)
+	}},
+	{Name: "role", Run: func(cfg *Config) error {
+		loaded, err := loadRoles(cfg.RolesPath)
+		if err != nil {
+			return err
+		}
+		if _, exists := loaded[cfg.Role]; !exists {
+			return fmt.Errorf("role '%s' tidak terdaftar", cfg.Role)
+		}
+		return nil
+	}},
+	{Name: "pesanan ditahan", Run: func(cfg *Config) error {
+		_, err := NewHeldOrders(cfg.HeldOrdersPath).List()
+		return err
+	}},
+	{Name: "voucher Wi-Fi", Run: func(cfg *Config) error {
+		if cfg.WifiVoucherPath == "" {
+			return nil
+		}
+		available, _, err := NewWifiVoucherPool(cfg.WifiVoucherPath).Status()
+		if err == nil && available == 0 {
+			return fmt.Errorf("voucher Wi-Fi habis")
+		}
+		return err
+	}},
+	{Name: "jam sistem", Run: func(cfg *Config) error {
+		if now := clock.Now(); now.Year() < 2024 {
+			return fmt.Errorf("jam sistem tampaknya salah: %s", now.Format(time.RFC3339))
+		}
+		return nil
+	}},
+}
+
+// runDoctor menjalankan semua pemeriksaan dan mencetak laporan lulus/gagal.
+// Mengembalikan false jika ada pemeriksaan yang gagal.
+func runDoctor(cfg *Config, w io.Writer) bool {
+	ok := true
+	fmt.Fprintln(w, "Pemeriksaan sistem:")
+	for _, check := range doctorChecks {
+		if err := check.Run(cfg); err != nil {
+			ok = false
+			fmt.Fprintf(w, "[GAGAL] %s: %v\n", check.Name, err)
+			continue
+		}
+		fmt.Fprintf(w, "[OK]    %s\n", check.Name)
+	}
+	return ok
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDoctorClockCheck(t *testing.T) {
+	var check func(*Config) error
+	for _, c := range doctorChecks {
+		if c.Name == "jam sistem" {
+			check = c.Run
+		}
+	}
+	if check == nil {
+		t.Fatal("pemeriksaan jam sistem tidak ditemukan")
+	}
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		wantErr bool
+	}{
+		{"jam wajar", time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC), false},
+		{"jam mundur", time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withClock(t, &fixedClock{t: tt.at})
+			if err := check(&Config{}); (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, ingin gagal %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/base64"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// EncoderStats berisi metrik alokasi encoder pesanan
+type EncoderStats struct {
+	Encoded     uint64 // jumlah pesanan yang sudah di-encode
+	BufferAlloc uint64 // jumlah buffer baru yang dialokasikan pool
+	Bytes       uint64 // total byte hasil encode
+}
+
+var (
+	encodedCount     atomic.Uint64
+	bufferAllocCount atomic.Uint64
+	encodedBytes     atomic.Uint64
+)
+
+// encodeBufPool menyimpan buffer yang bisa dipakai ulang antar pesanan
+var encodeBufPool = sync.Pool{
+	New: func() any {
+		bufferAllocCount.Add(1)
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+// encodeOrderSummary meng-encode ringkasan pembayaran pesanan ke base64
+// memakai buffer dari pool agar tidak mengalokasi ulang setiap pesanan
+func encodeOrderSummary(order *Order) string {
+	rawPtr := encodeBufPool.Get().(*[]byte)
+	outPtr := encodeBufPool.Get().(*[]byte)
+
+	raw := (*rawPtr)[:0]
+	raw = append(raw, "Total: "...)
+	raw = strconv.AppendFloat(raw, order.Total, 'f', 2, 64)
+	raw = append(raw, ", Payment: "...)
+	raw = strconv.AppendFloat(raw, order.Payment, 'f', 2, 64)
+	raw = append(raw, ", Change: "...)
+	raw = strconv.AppendFloat(raw, order.Change, 'f', 2, 64)
+
+	out := base64.StdEncoding.AppendEncode((*outPtr)[:0], raw)
+	encoded := string(out)
+
+	*rawPtr, *outPtr = raw, out
+	encodeBufPool.Put(rawPtr)
+	encodeBufPool.Put(outPtr)
+
+	encodedCount.Add(1)
+	encodedBytes.Add(uint64(len(encoded)))
+	return encoded
+}
+
+// CurrentEncoderStats mengembalikan metrik encoder sejak program mulai
+func CurrentEncoderStats() EncoderStats {
+	return EncoderStats{
+		Encoded:     encodedCount.Load(),
+		BufferAlloc: bufferAllocCount.Load(),
+		Bytes:       encodedBytes.Load(),
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestEncodeOrderSummary(t *testing.T) {
+	order := &Order{Total: 50000, Payment: 100000, Change: 50000}
+	before := CurrentEncoderStats()
+
+	encoded := encodeOrderSummary(order)
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Total: 50000.00, Payment: 100000.00, Change: 50000.00"; string(decoded) != want {
+		t.Errorf("ringkasan = %q, ingin %q", decoded, want)
+	}
+
+	after := CurrentEncoderStats()
+	if after.Encoded != before.Encoded+1 {
+		t.Errorf("Encoded = %d, ingin %d", after.Encoded, before.Encoded+1)
+	}
+	if after.Bytes != before.Bytes+uint64(len(encoded)) {
+		t.Errorf("Bytes = %d, ingin %d", after.Bytes, before.Bytes+uint64(len(encoded)))
+	}
+}
+
+func BenchmarkEncodeOrderSummary(b *testing.B) {
+	order := &Order{Total: 123456.78, Payment: 150000, Change: 26543.22}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		encodeOrderSummary(order)
+	}
+}
@@ -0,0 +1,264 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Codec format serialisasi untuk tipe di package ini. JSON dan MessagePack
+// memakai tag `json` yang sama, sehingga nama field selalu konsisten.
+type Codec interface {
+	ContentType() string
+	Marshal(v any) ([]byte, error)
+}
+
+// JSON codec JSON standar
+var JSON Codec = jsonCodec{}
+
+// MsgPack codec MessagePack untuk klien dengan bandwidth terbatas
+var MsgPack Codec = msgpackCodec{}
+
+// ErrNotAcceptable dikembalikan jika header Accept tidak cocok dengan codec mana pun
+var ErrNotAcceptable = errors.New("format respons tidak didukung")
+
+type jsonCodec struct{}
+
+func (jsonCodec) ContentType() string           { return "application/json" }
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) ContentType() string { return "application/msgpack" }
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	var b []byte
+	return appendMsgPack(b, reflect.ValueOf(v))
+}
+
+// Negotiate memilih codec berdasarkan header Accept. Header kosong berarti JSON.
+// Nilai q dihormati; jika sama, urutan di header yang menentukan.
+func Negotiate(accept string) (Codec, error) {
+	if strings.TrimSpace(accept) == "" {
+		return JSON, nil
+	}
+	var best Codec
+	bestQ := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(s, 64); err != nil {
+				continue
+			}
+		}
+		var codec Codec
+		switch mediaType {
+		case "application/msgpack", "application/x-msgpack":
+			codec = MsgPack
+		case "application/json", "application/*", "*/*":
+			codec = JSON
+		default:
+			continue
+		}
+		if q > bestQ {
+			best, bestQ = codec, q
+		}
+	}
+	if best == nil {
+		return nil, ErrNotAcceptable
+	}
+	return best, nil
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// appendMsgPack meng-encode v ke format MessagePack dengan aturan nama field
+// dan omitempty yang sama seperti encoding/json
+func appendMsgPack(b []byte, v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		return append(b, 0xc0), nil
+	}
+	switch v.Type() {
+	case timeType:
+		return appendMsgPackString(b, v.Interface().(time.Time).Format(time.RFC3339Nano)), nil
+	case rawMessageType:
+		// Isi RawMessage (JSON) diubah ke nilai biasa lalu di-encode ulang
+		var decoded any
+		if err := json.Unmarshal(v.Bytes(), &decoded); err != nil {
+			return nil, err
+		}
+		return appendMsgPack(b, reflect.ValueOf(decoded))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		return appendMsgPack(b, v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendMsgPackInt(b, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u := v.Uint()
+		if u > math.MaxInt64 {
+			return append(b, 0xcf, byte(u>>56), byte(u>>48), byte(u>>40), byte(u>>32), byte(u>>24), byte(u>>16), byte(u>>8), byte(u)), nil
+		}
+		return appendMsgPackInt(b, int64(u)), nil
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		// Bilangan bulat dikirim sebagai integer agar lebih ringkas, seperti JSON
+		if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			return appendMsgPackInt(b, int64(f)), nil
+		}
+		bits := math.Float64bits(f)
+		return append(b, 0xcb, byte(bits>>56), byte(bits>>48), byte(bits>>40), byte(bits>>32), byte(bits>>24), byte(bits>>16), byte(bits>>8), byte(bits)), nil
+	case reflect.String:
+		return appendMsgPackString(b, v.String()), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		b = appendMsgPackLen(b, v.Len(), 0x90, 0xdc, 0xdd)
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if b, err = appendMsgPack(b, v.Index(i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return append(b, 0xc0), nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("msgpack: key map %s tidak didukung", v.Type().Key())
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		b = appendMsgPackLen(b, len(keys), 0x80, 0xde, 0xdf)
+		for _, k := range keys {
+			b = appendMsgPackString(b, k.String())
+			var err error
+			if b, err = appendMsgPack(b, v.MapIndex(k)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Struct:
+		return appendMsgPackStruct(b, v)
+	default:
+		return nil, fmt.Errorf("msgpack: tipe %s tidak didukung", v.Type())
+	}
+}
+
+// appendMsgPackStruct meng-encode struct sebagai map dengan nama dari tag json
+func appendMsgPackStruct(b []byte, v reflect.Value) ([]byte, error) {
+	type field struct {
+		name  string
+		value reflect.Value
+	}
+	var fields []field
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.Contains(opts, "omitempty") && isEmptyValue(v.Field(i)) {
+			continue
+		}
+		fields = append(fields, field{name, v.Field(i)})
+	}
+
+	b = appendMsgPackLen(b, len(fields), 0x80, 0xde, 0xdf)
+	for _, f := range fields {
+		b = appendMsgPackString(b, f.name)
+		var err error
+		if b, err = appendMsgPack(b, f.value); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// isEmptyValue aturan omitempty yang sama dengan encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// appendMsgPackInt meng-encode integer dengan format terpendek
+func appendMsgPackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= 0x7f:
+		return append(b, byte(n))
+	case n < 0 && n >= -32:
+		return append(b, byte(n))
+	case n >= math.MinInt8 && n <= math.MaxInt8:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16 && n <= math.MaxInt16:
+		return append(b, 0xd1, byte(n>>8), byte(n))
+	case n >= math.MinInt32 && n <= math.MaxInt32:
+		return append(b, 0xd2, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	default:
+		return append(b, 0xd3, byte(n>>56), byte(n>>48), byte(n>>40), byte(n>>32), byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
+
+// appendMsgPackString meng-encode string UTF-8
+func appendMsgPackString(b []byte, s string) []byte {
+	if len(s) <= 31 {
+		b = append(b, 0xa0|byte(len(s)))
+	} else if len(s) <= math.MaxUint8 {
+		b = append(b, 0xd9, byte(len(s)))
+	} else {
+		b = appendMsgPackLen(b, len(s), 0, 0xda, 0xdb)
+	}
+	return append(b, s...)
+}
+
+// appendMsgPackLen menulis header panjang array/map/string. fix adalah prefix
+// format ringkas (panjang <= 15), 0 jika tidak ada.
+func appendMsgPackLen(b []byte, n int, fix, code16, code32 byte) []byte {
+	switch {
+	case fix != 0 && n <= 15:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return append(b, code16, byte(n>>8), byte(n))
+	default:
+		return append(b, code32, byte(n>>24), byte(n>>16), byte(n>>8), byte(n))
+	}
+}
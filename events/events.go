@@ -0,0 +1,175 @@
+// Package events mendefinisikan event domain POS dengan versi skema eksplisit.
+// Konsumen (hook, webhook, antrean pesan, penyimpanan event) cukup bergantung
+// pada tipe di package ini, bukan pada struct internal program kasir.
+//
+// Setiap perubahan yang tidak kompatibel pada isi event harus menjadi tipe baru
+// dengan versi berikutnya (misalnya OrderPaidV2), tipe lama tetap dipertahankan.
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Nama tipe event
+const (
+	TypeOrderCreated   = "order.created"
+	TypeOrderPaid      = "order.paid"
+	TypeStockLow       = "stock.low"
+	TypeOrderCancelled = "order.cancelled"
+)
+
+// ErrUnknownEvent dikembalikan jika kombinasi tipe dan versi tidak dikenal
+var ErrUnknownEvent = errors.New("event tidak dikenal")
+
+// Event isi satu event domain
+type Event interface {
+	EventType() string
+	SchemaVersion() int
+}
+
+// Envelope pembungkus event saat dikirim atau disimpan
+type Envelope struct {
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schema_version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// LineItemV1 satu baris item pada pesanan
+type LineItemV1 struct {
+	Name     string `json:"name"`
+	SKU      string `json:"sku,omitempty"`
+	Category string `json:"category,omitempty"`
+	Quantity int    `json:"quantity"` // dibulatkan ke atas untuk jumlah pecahan, lihat Amount
+	// Unit dan Amount diisi untuk item yang dijual per satuan (misalnya per 100g);
+	// Amount jumlah persisnya, bisa pecahan
+	Unit      string   `json:"unit,omitempty"`
+	Amount    float64  `json:"amount,omitempty"`
+	UnitPrice float64  `json:"unit_price"`
+	Total     float64  `json:"total"`
+	Seat      int      `json:"seat,omitempty"`
+	Modifiers []string `json:"modifiers,omitempty"`
+	Note      string   `json:"note,omitempty"` // catatan untuk dapur
+	// Components isi paket untuk dapur, jumlah per satu paket
+	Components []ComponentV1 `json:"components,omitempty"`
+}
+
+// ComponentV1 satu item isi paket
+type ComponentV1 struct {
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+// OrderCreatedV1 pesanan sudah dikonfirmasi pelanggan
+type OrderCreatedV1 struct {
+	OrderID      string       `json:"order_id,omitempty"`
+	TrackingCode string       `json:"tracking_code"`
+	Items        []LineItemV1 `json:"items"`
+	Total        float64      `json:"total"`
+}
+
+func (OrderCreatedV1) EventType() string  { return TypeOrderCreated }
+func (OrderCreatedV1) SchemaVersion() int { return 1 }
+
+// OrderPaidV1 pembayaran pesanan sudah diterima
+type OrderPaidV1 struct {
+	OrderID      string  `json:"order_id,omitempty"`
+	TrackingCode string  `json:"tracking_code"`
+	QueueNumber  int     `json:"queue_number,omitempty"`
+	Total        float64 `json:"total"`
+	Discount     float64 `json:"discount,omitempty"`
+	Payment      float64 `json:"payment"`
+	Change       float64 `json:"change"`
+}
+
+func (OrderPaidV1) EventType() string  { return TypeOrderPaid }
+func (OrderPaidV1) SchemaVersion() int { return 1 }
+
+// OrderCancelledV1 pesanan dibatalkan sebelum selesai diproses
+type OrderCancelledV1 struct {
+	OrderID      string       `json:"order_id"`
+	TrackingCode string       `json:"tracking_code"`
+	Reason       string       `json:"reason"`
+	Items        []LineItemV1 `json:"items"`
+	Total        float64      `json:"total"`
+}
+
+func (OrderCancelledV1) EventType() string  { return TypeOrderCancelled }
+func (OrderCancelledV1) SchemaVersion() int { return 1 }
+
+// StockLowV1 stok item turun sampai batas yang ditentukan
+type StockLowV1 struct {
+	Store     string `json:"store"`
+	Item      string `json:"item"`
+	SKU       string `json:"sku,omitempty"`
+	Remaining int    `json:"remaining"`
+	Threshold int    `json:"threshold"`
+}
+
+func (StockLowV1) EventType() string  { return TypeStockLow }
+func (StockLowV1) SchemaVersion() int { return 1 }
+
+// MenuItemV1 item menu publik, misalnya untuk menu web atau tablet pelanggan.
+// Jumlah stok tidak disertakan, hanya status habis.
+type MenuItemV1 struct {
+	Name        string         `json:"name"`
+	Price       float64        `json:"price"`
+	Unit        string         `json:"unit,omitempty"`
+	Category    string         `json:"category"`
+	Description string         `json:"description,omitempty"`
+	ImageURL    string         `json:"image_url,omitempty"`
+	SoldOut     bool           `json:"sold_out,omitempty"`
+	Available   []TimeWindowV1 `json:"available,omitempty"`
+	ValidFrom   string         `json:"valid_from,omitempty"`
+	ValidUntil  string         `json:"valid_until,omitempty"`
+	Bundle      []ComponentV1  `json:"bundle,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+}
+
+// TimeWindowV1 rentang jam "HH:MM"
+type TimeWindowV1 struct {
+	From  string `json:"from"`
+	Until string `json:"until"`
+}
+
+// decoders pembuat event kosong per tipe dan versi
+var decoders = map[string]map[int]func() Event{
+	TypeOrderCreated:   {1: func() Event { return &OrderCreatedV1{} }},
+	TypeOrderPaid:      {1: func() Event { return &OrderPaidV1{} }},
+	TypeStockLow:       {1: func() Event { return &StockLowV1{} }},
+	TypeOrderCancelled: {1: func() Event { return &OrderCancelledV1{} }},
+}
+
+// Encode membungkus event dalam Envelope lalu mengubahnya ke JSON
+func Encode(e Event, occurredAt time.Time) ([]byte, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("gagal encode event %s: %w", e.EventType(), err)
+	}
+	return json.Marshal(Envelope{
+		Type:          e.EventType(),
+		SchemaVersion: e.SchemaVersion(),
+		OccurredAt:    occurredAt.UTC(),
+		Data:          data,
+	})
+}
+
+// Decode membaca Envelope JSON dan mengembalikan event sesuai tipe dan versinya
+func Decode(b []byte) (Envelope, Event, error) {
+	var env Envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return env, nil, fmt.Errorf("format event tidak valid: %w", err)
+	}
+	newEvent, ok := decoders[env.Type][env.SchemaVersion]
+	if !ok {
+		return env, nil, fmt.Errorf("%w: %s v%d", ErrUnknownEvent, env.Type, env.SchemaVersion)
+	}
+	e := newEvent()
+	if err := json.Unmarshal(env.Data, e); err != nil {
+		return env, nil, fmt.Errorf("isi event %s tidak valid: %w", env.Type, err)
+	}
+	return env, e, nil
+}
@@ -0,0 +1,182 @@
+package events_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"TUGAS_2MKTI/events"
+)
+
+// occurredAt waktu event untuk test, sengaja bukan UTC
+var occurredAt = time.Date(2024, 5, 17, 19, 30, 0, 0, time.FixedZone("WIB", 7*3600))
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	tests := []events.Event{
+		&events.OrderCreatedV1{
+			OrderID:      "01HXZ",
+			TrackingCode: "AR7-29K",
+			Items: []events.LineItemV1{
+				{Name: "Nasi Goreng", SKU: "MKN-001", Category: "makanan", Quantity: 2, UnitPrice: 25000, Total: 50000, Seat: 1, Modifiers: []string{"pedas"}, Note: "tanpa bawang"},
+				{Name: "Paket Hemat", Quantity: 1, UnitPrice: 40000, Total: 40000, Components: []events.ComponentV1{{Name: "es teh", Quantity: 1}}},
+				{Name: "Kopi Bubuk", Quantity: 3, Unit: "100g", Amount: 2.5, UnitPrice: 15000, Total: 37500},
+			},
+			Total: 127500,
+		},
+		&events.OrderPaidV1{OrderID: "01HXZ", TrackingCode: "AR7-29K", QueueNumber: 12, Total: 127500, Discount: 5000, Payment: 150000, Change: 22500},
+		&events.OrderCancelledV1{OrderID: "01HXZ", TrackingCode: "AR7-29K", Reason: "salah_input", Items: []events.LineItemV1{{Name: "Nasi Goreng", Quantity: 1, UnitPrice: 25000, Total: 25000}}, Total: 25000},
+		&events.StockLowV1{Store: "jkt-01", Item: "ayam bakar", SKU: "MKN-002", Remaining: 3, Threshold: 5},
+	}
+	for _, want := range tests {
+		t.Run(want.EventType(), func(t *testing.T) {
+			b, err := events.Encode(want, occurredAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			env, got, err := events.Decode(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if env.Type != want.EventType() || env.SchemaVersion != want.SchemaVersion() {
+				t.Errorf("envelope %s v%d, ingin %s v%d", env.Type, env.SchemaVersion, want.EventType(), want.SchemaVersion())
+			}
+			if !env.OccurredAt.Equal(occurredAt) || env.OccurredAt.Location() != time.UTC {
+				t.Errorf("occurred_at = %v, ingin %v dalam UTC", env.OccurredAt, occurredAt)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("hasil decode = %+v, ingin %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestEncodeWireFormat mengunci nama field dan omitempty yang dipakai konsumen event.
+// Jika test ini gagal, perubahannya tidak kompatibel dan harus menjadi versi skema baru.
+func TestEncodeWireFormat(t *testing.T) {
+	tests := []struct {
+		event events.Event
+		want  string
+	}{
+		{
+			&events.OrderPaidV1{TrackingCode: "AR7-29K", Total: 50000, Payment: 50000},
+			`{"type":"order.paid","schema_version":1,"occurred_at":"2024-05-17T12:30:00Z","data":{"tracking_code":"AR7-29K","total":50000,"payment":50000,"change":0}}`,
+		},
+		{
+			&events.StockLowV1{Store: "jkt-01", Item: "ayam bakar", Remaining: 3, Threshold: 5},
+			`{"type":"stock.low","schema_version":1,"occurred_at":"2024-05-17T12:30:00Z","data":{"store":"jkt-01","item":"ayam bakar","remaining":3,"threshold":5}}`,
+		},
+		{
+			&events.OrderCancelledV1{OrderID: "01HXZ", TrackingCode: "AR7-29K", Reason: "lainnya", Items: []events.LineItemV1{{Name: "Es Teh", Quantity: 1, UnitPrice: 5000, Total: 5000}}, Total: 5000},
+			`{"type":"order.cancelled","schema_version":1,"occurred_at":"2024-05-17T12:30:00Z","data":{"order_id":"01HXZ","tracking_code":"AR7-29K","reason":"lainnya","items":[{"name":"Es Teh","quantity":1,"unit_price":5000,"total":5000}],"total":5000}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.event.EventType(), func(t *testing.T) {
+			b, err := events.Encode(tt.event, occurredAt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("Encode =\n%s\ningin\n%s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsUnknownEvents(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"tipe tidak dikenal", `{"type":"order.refunded","schema_version":1,"data":{}}`},
+		{"versi tidak dikenal", `{"type":"order.paid","schema_version":2,"data":{}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, _, err := events.Decode([]byte(tt.input))
+			if !errors.Is(err, events.ErrUnknownEvent) {
+				t.Fatalf("error = %v, ingin %v", err, events.ErrUnknownEvent)
+			}
+			// Envelope tetap dikembalikan agar konsumen bisa mencatat atau melewati event
+			if env.Type == "" {
+				t.Error("envelope kosong untuk event yang tidak dikenal")
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidInput(t *testing.T) {
+	for _, input := range []string{
+		`bukan json`,
+		`{"type":"order.paid","schema_version":1,"data":{"total":"lima puluh"}}`,
+	} {
+		if _, _, err := events.Decode([]byte(input)); err == nil || errors.Is(err, events.ErrUnknownEvent) {
+			t.Errorf("Decode(%s) error = %v, ingin error format", input, err)
+		}
+	}
+}
+
+func TestDecodeIgnoresUnknownFields(t *testing.T) {
+	// Field tambahan pada versi yang sama tidak boleh merusak konsumen lama
+	input := `{"type":"order.paid","schema_version":1,"occurred_at":"2024-05-17T12:30:00Z","data":{"tracking_code":"AR7-29K","total":50000,"payment":50000,"change":0,"tip":2000},"trace_id":"x"}`
+	_, e, err := events.Decode([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	paid, ok := e.(*events.OrderPaidV1)
+	if !ok || paid.TrackingCode != "AR7-29K" || paid.Total != 50000 {
+		t.Errorf("hasil decode = %+v", e)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   events.Codec
+	}{
+		{"", events.JSON},
+		{"application/json", events.JSON},
+		{"*/*", events.JSON},
+		{"application/msgpack", events.MsgPack},
+		{"application/x-msgpack", events.MsgPack},
+		{"application/json;q=0.5, application/msgpack", events.MsgPack},
+		{"application/msgpack;q=0.1, application/json;q=0.9", events.JSON},
+		{"application/msgpack, application/json", events.MsgPack},
+		{"text/html, application/json;q=0.8", events.JSON},
+	}
+	for _, tt := range tests {
+		got, err := events.Negotiate(tt.accept)
+		if err != nil {
+			t.Errorf("Negotiate(%q): %v", tt.accept, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Negotiate(%q) = %s, ingin %s", tt.accept, got.ContentType(), tt.want.ContentType())
+		}
+	}
+
+	if _, err := events.Negotiate("text/html"); !errors.Is(err, events.ErrNotAcceptable) {
+		t.Errorf("Negotiate(text/html) error = %v, ingin %v", err, events.ErrNotAcceptable)
+	}
+}
+
+func TestMsgPackUsesJSONFieldNames(t *testing.T) {
+	item := events.MenuItemV1{Name: "es teh", Price: 5000, Category: "minuman"}
+	b, err := events.MsgPack.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Map 3 field dengan nama dari tag json; field omitempty yang kosong dilewati,
+	// harga bulat dikirim sebagai integer
+	want := []byte{0x83}
+	want = append(append(want, 0xa4), "name"...)
+	want = append(append(want, 0xa6), "es teh"...)
+	want = append(append(want, 0xa5), "price"...)
+	want = append(want, 0xd1, 0x13, 0x88)
+	want = append(append(want, 0xa8), "category"...)
+	want = append(append(want, 0xa7), "minuman"...)
+	if string(b) != string(want) {
+		t.Errorf("msgpack = %x, ingin %x", b, want)
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// flagPricingEngine flag untuk meluncurkan pricing engine baru secara bertahap.
+// Variant berisi nama engine yang dipakai untuk pesanan yang terkena flag.
+const flagPricingEngine = "pricing_engine"
+
+// FeatureFlag mengatur peluncuran bertahap sebuah fitur
+type FeatureFlag struct {
+	Enabled bool     `json:"enabled"`
+	Percent *int     `json:"percent,omitempty"` // persentase pesanan yang terkena, kosong berarti semua
+	Stores  []string `json:"stores"`            // kosong berarti semua toko
+	Variant string   `json:"variant"`
+}
+
+var (
+	flagsMu      sync.RWMutex
+	featureFlags = map[string]FeatureFlag{}
+	// storeID kode toko yang sedang berjalan, dipakai untuk flag per toko
+	storeID string
+)
+
+// loadFeatureFlags membaca flag dari file JSON
+func loadFeatureFlags(path string) error {
+	if path == "" {
+		return nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gagal membaca feature flag: %w", err)
+	}
+
+	var flags map[string]FeatureFlag
+	if err := json.Unmarshal(b, &flags); err != nil {
+		return fmt.Errorf("format feature flag tidak valid: %w", err)
+	}
+	for name, flag := range flags {
+		if err := validateFeatureFlag(name, flag); err != nil {
+			return err
+		}
+	}
+
+	flagsMu.Lock()
+	defer flagsMu.Unlock()
+	featureFlags = flags
+	return nil
+}
+
+// validateFeatureFlag memeriksa nilai flag
+func validateFeatureFlag(name string, flag FeatureFlag) error {
+	if flag.Percent != nil && (*flag.Percent < 0 || *flag.Percent > 100) {
+		return fmt.Errorf("persentase flag '%s' harus 0-100", name)
+	}
+	if name == flagPricingEngine && flag.Variant != "" {
+		pricingMu.RLock()
+		_, exists := pricingEngines[flag.Variant]
+		pricingMu.RUnlock()
+		if !exists {
+			return fmt.Errorf("pricing engine '%s' pada flag '%s' tidak terdaftar", flag.Variant, name)
+		}
+	}
+	return nil
+}
+
+// FeatureEnabled mengecek apakah flag aktif untuk toko ini dan pesanan dengan key tertentu.
+// Pesanan yang sama selalu mendapat hasil yang sama.
+func FeatureEnabled(name, key string) (FeatureFlag, bool) {
+	flagsMu.RLock()
+	flag, exists := featureFlags[name]
+	flagsMu.RUnlock()
+
+	if !exists || !flag.Enabled {
+		return flag, false
+	}
+	if len(flag.Stores) > 0 {
+		found := false
+		for _, s := range flag.Stores {
+			if s == storeID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return flag, false
+		}
+	}
+	if flag.Percent == nil {
+		return flag, true
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(name + ":" + key))
+	return flag, int(h.Sum32()%100) < *flag.Percent
+}
+
+// percentLabel persentase flag untuk ditampilkan, "semua" jika tidak dibatasi
+func (f FeatureFlag) percentLabel() string {
+	if f.Percent == nil {
+		return "semua"
+	}
+	return strconv.Itoa(*f.Percent)
+}
+
+// SetFeatureFlag mengubah flag saat program berjalan
+func SetFeatureFlag(name string, flag FeatureFlag) error {
+	if err := validateFeatureFlag(name, flag); err != nil {
+		return err
+	}
+	flagsMu.Lock()
+	defer flagsMu.Unlock()
+	featureFlags[name] = flag
+	return nil
+}
+
+// runFeatureCommand menjalankan perintah "fitur" di prompt kasir:
+// "fitur" menampilkan semua flag, "fitur <nama> on|off|<persen>" mengubahnya.
+// Hanya flag dari file -flags dan flag bawaan yang bisa diubah.
+func runFeatureCommand(input string) error {
+	args := strings.Fields(strings.TrimPrefix(input, "fitur"))
+	if len(args) == 0 {
+		flagsMu.RLock()
+		names := make([]string, 0, len(featureFlags))
+		for name := range featureFlags {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Println("\nFeature flag:")
+		for _, name := range names {
+			f := featureFlags[name]
+			fmt.Printf("- %s: aktif=%v persen=%s toko=%v varian=%s\n", name, f.Enabled, f.percentLabel(), f.Stores, f.Variant)
+		}
+		flagsMu.RUnlock()
+		return nil
+	}
+	if len(args) != 2 {
+		return fmt.Errorf("gunakan: fitur <nama> on|off|<persen>")
+	}
+
+	flagsMu.RLock()
+	flag, exists := featureFlags[args[0]]
+	flagsMu.RUnlock()
+	// Salah ketik tidak boleh diam-diam membuat flag baru
+	if !exists && args[0] != flagPricingEngine {
+		return fmt.Errorf("flag '%s' tidak dikenal, ketik 'fitur' untuk melihat daftar flag", args[0])
+	}
+
+	switch args[1] {
+	case "on":
+		// "on" berarti aktif untuk semua pesanan, persentase sebelumnya dihapus
+		flag.Enabled, flag.Percent = true, nil
+	case "off":
+		flag.Enabled = false
+	default:
+		percent, err := strconv.Atoi(args[1])
+		if err != nil {
+			return fmt.Errorf("nilai flag tidak valid: %s", args[1])
+		}
+		flag.Enabled, flag.Percent = true, &percent
+	}
+	if err := SetFeatureFlag(args[0], flag); err != nil {
+		return err
+	}
+	fmt.Printf("Flag '%s' diperbarui\n", args[0])
+	return nil
+}
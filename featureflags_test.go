@@ -0,0 +1,29 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunFeatureCommandKnownFlags(t *testing.T) {
+	defer func(flags map[string]FeatureFlag) { featureFlags = flags }(featureFlags)
+	featureFlags = map[string]FeatureFlag{"menu_baru": {}}
+
+	if err := runFeatureCommand("fitur menu_baru on"); err != nil {
+		t.Fatal(err)
+	}
+	if !featureFlags["menu_baru"].Enabled {
+		t.Error("flag menu_baru tidak diaktifkan")
+	}
+	if err := runFeatureCommand("fitur " + flagPricingEngine + " 10"); err != nil {
+		t.Errorf("flag bawaan ditolak: %v", err)
+	}
+
+	err := runFeatureCommand("fitur menu_bary on")
+	if err == nil || !strings.Contains(err.Error(), "tidak dikenal") {
+		t.Fatalf("error = %v, ingin flag tidak dikenal", err)
+	}
+	if _, exists := featureFlags["menu_bary"]; exists {
+		t.Error("salah ketik membuat flag baru")
+	}
+}
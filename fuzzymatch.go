@@ -0,0 +1,73 @@
+package main
+
+import "strings"
+
+// maxTypoDistance batas jarak edit agar nama dianggap salah ketik
+const maxTypoDistance = 2
+
+// suggestCandidate teks yang dibandingkan dengan input beserta nama item menunya
+type suggestCandidate struct {
+	text string
+	name string
+}
+
+// suggestMenuItem mencari item menu yang paling mirip dengan input:
+// pertama lewat awalan yang unik, lalu lewat jarak edit (Levenshtein).
+// Nama dan alias sama-sama dibandingkan; alias yang cocok disarankan sebagai
+// item aslinya. Hanya item yang sedang tersedia yang disarankan.
+func suggestMenuItem(input string, menu MenuRepository) (MenuEntry, bool) {
+	var candidates []suggestCandidate
+	for _, entry := range availableMenu(menu.List(), clock.Now()) {
+		candidates = append(candidates, suggestCandidate{text: entry.Name, name: entry.Name})
+		for _, alias := range entry.Aliases {
+			candidates = append(candidates, suggestCandidate{text: alias, name: entry.Name})
+		}
+	}
+
+	// Awalan dianggap unik jika semua yang cocok mengarah ke item yang sama
+	prefixed := make(map[string]bool)
+	for _, c := range candidates {
+		if strings.HasPrefix(c.text, input) {
+			prefixed[c.name] = true
+		}
+	}
+	if len(prefixed) == 1 {
+		for name := range prefixed {
+			return menu.Get(name)
+		}
+	}
+
+	best, bestDist := "", maxTypoDistance+1
+	for _, c := range candidates {
+		if d := levenshtein(input, c.text); d < bestDist {
+			best, bestDist = c.name, d
+		}
+	}
+	if best == "" {
+		return MenuEntry{}, false
+	}
+	return menu.Get(best)
+}
+
+// levenshtein menghitung jarak edit antara dua string
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestSuggestMenuItemAliases(t *testing.T) {
+	menu := NewInMemoryMenuRepository(map[string]MenuEntry{
+		"nasi goreng": {Name: "nasi goreng", Price: 25000, Category: "makanan", Aliases: []string{"nasgor"}},
+		"es teh":      {Name: "es teh", Price: 5000, Category: "minuman", Aliases: []string{"teh es"}},
+		"es jeruk":    {Name: "es jeruk", Price: 7000, Category: "minuman"},
+	})
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"nasi gorng", "nasi goreng"}, // salah ketik nama
+		{"nasgr", "nasi goreng"},      // salah ketik alias
+		{"nasg", "nasi goreng"},       // awalan alias
+		{"teh", "es teh"},             // awalan alias, bukan awalan nama
+		{"es", ""},                    // awalan ke dua item berbeda
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, found := suggestMenuItem(tt.input, menu)
+			if tt.want == "" {
+				if found {
+					t.Errorf("saran = %s, ingin tidak ada", got.Name)
+				}
+				return
+			}
+			if !found || got.Name != tt.want {
+				t.Errorf("saran = %q (%v), ingin %q", got.Name, found, tt.want)
+			}
+		})
+	}
+}
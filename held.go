@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultHeldOrdersPath file pesanan yang ditahan jika -held-orders tidak diisi
+const defaultHeldOrdersPath = "pesanan-ditahan.json"
+
+// heldOrder satu pesanan yang ditahan, misalnya karena pelanggan pergi sebentar
+type heldOrder struct {
+	ID           string         `json:"id"`
+	TrackingCode string         `json:"tracking_code"`
+	Table        string         `json:"table"`
+	CreatedAt    time.Time      `json:"created_at"`
+	HeldAt       time.Time      `json:"held_at"`
+	Training     bool           `json:"training,omitempty"`
+	Items        []*MenuItem    `json:"items"`
+	Discount     *OrderDiscount `json:"discount,omitempty"`
+	// CustomFields field tambahan yang sudah diisi sebelum pesanan ditahan
+	CustomFields []CustomFieldValue `json:"custom_fields,omitempty"`
+}
+
+// HeldOrders menyimpan pesanan yang ditahan di file JSON sehingga tetap ada
+// walaupun program ditutup
+type HeldOrders struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewHeldOrders membuat penyimpanan pesanan ditahan di path
+func NewHeldOrders(path string) *HeldOrders {
+	return &HeldOrders{path: path}
+}
+
+// load membaca semua pesanan yang ditahan; file yang belum ada berarti kosong
+func (h *HeldOrders) load() ([]heldOrder, error) {
+	b, err := os.ReadFile(h.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca pesanan ditahan: %w", err)
+	}
+	var held []heldOrder
+	if err := json.Unmarshal(b, &held); err != nil {
+		return nil, fmt.Errorf("file pesanan ditahan tidak valid: %w", err)
+	}
+	return held, nil
+}
+
+// save menulis ulang semua pesanan yang ditahan
+func (h *HeldOrders) save(held []heldOrder) error {
+	b, err := json.MarshalIndent(held, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(h.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("gagal menyimpan pesanan ditahan: %w", err)
+	}
+	return nil
+}
+
+// Hold menyimpan pesanan yang belum selesai beserta nomor mejanya
+func (h *HeldOrders) Hold(table string, order *Order) error {
+	if err := order.requireDraft(); err != nil {
+		return err
+	}
+	if len(order.Items) == 0 {
+		return fmt.Errorf("pesanan kosong tidak perlu ditahan")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	held, err := h.load()
+	if err != nil {
+		return err
+	}
+	held = append(held, heldOrder{
+		ID:           order.ID,
+		TrackingCode: order.TrackingCode,
+		Table:        table,
+		CreatedAt:    order.CreatedAt,
+		HeldAt:       clock.Now(),
+		Training:     order.Training,
+		Items:        order.Items,
+		Discount:     order.Discount,
+		CustomFields: order.CustomFields,
+	})
+	return h.save(held)
+}
+
+// Resume mengambil kembali pesanan berdasarkan ID atau kode pesanan lalu
+// menghapusnya dari daftar tahanan. Total dihitung ulang dengan tarif saat ini.
+func (h *HeldOrders) Resume(key string) (*Order, string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	held, err := h.load()
+	if err != nil {
+		return nil, "", err
+	}
+	for i, record := range held {
+		if !strings.EqualFold(record.ID, key) && !strings.EqualFold(record.TrackingCode, key) {
+			continue
+		}
+		if err := h.save(append(held[:i], held[i+1:]...)); err != nil {
+			return nil, "", err
+		}
+		order := &Order{
+			ID:           record.ID,
+			CreatedAt:    record.CreatedAt,
+			Status:       StatusDraft,
+			TrackingCode: record.TrackingCode,
+			Training:     record.Training,
+			Items:        record.Items,
+			Discount:     record.Discount,
+			CustomFields: record.CustomFields,
+		}
+		order.calculateTotal()
+		return order, record.Table, nil
+	}
+	return nil, "", fmt.Errorf("pesanan ditahan '%s' tidak ditemukan", key)
+}
+
+// Orders mengembalikan pesanan yang ditahan sebagai draf, agar itemnya ikut
+// dihitung sebagai stok yang sudah dipesan oleh checkStock
+func (h *HeldOrders) Orders() ([]*Order, error) {
+	held, err := h.List()
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]*Order, 0, len(held))
+	for _, record := range held {
+		orders = append(orders, &Order{ID: record.ID, Status: StatusDraft, Items: record.Items})
+	}
+	return orders, nil
+}
+
+// List mengembalikan pesanan yang sedang ditahan, urut sesuai waktu ditahan
+func (h *HeldOrders) List() ([]heldOrder, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.load()
+}
+
+// printHeldOrders menampilkan daftar pesanan yang ditahan
+func printHeldOrders(w io.Writer, held []heldOrder) {
+	if len(held) == 0 {
+		fmt.Fprintln(w, "Tidak ada pesanan yang ditahan")
+		return
+	}
+
+	if plainOutput {
+		fmt.Fprintf(w, "\nAda %d pesanan ditahan.\n", len(held))
+	} else {
+		fmt.Fprintln(w, "\nPesanan ditahan:")
+	}
+	for _, record := range held {
+		if plainOutput {
+			fmt.Fprintf(w, "Kode pesanan %s, meja %s, %d item, ditahan pukul %s.\n", record.TrackingCode, record.Table, len(record.Items), record.HeldAt.Format("15:04"))
+			continue
+		}
+		fmt.Fprintf(w, "- %s (%s) meja %s, %d item, ditahan %s\n", record.TrackingCode, record.ID, record.Table, len(record.Items), record.HeldAt.Format("15:04"))
+	}
+}
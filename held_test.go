@@ -0,0 +1,115 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHoldResumeAcrossRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ditahan.json")
+
+	order := newTestOrder()
+	order.Items[0].Note = "tanpa bawang"
+	order.Items[1].Seat = 2
+	order.setCustomField("nomor kendaraan", "B 1234 XY")
+	if err := order.ApplyDiscount(DiscountPercent, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewHeldOrders(path).Hold("4", order); err != nil {
+		t.Fatal(err)
+	}
+
+	// Instance baru membaca file yang sama, seperti setelah program dibuka ulang
+	held := NewHeldOrders(path)
+	list, err := held.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].TrackingCode != order.TrackingCode || list[0].Table != "4" {
+		t.Fatalf("pesanan ditahan = %+v", list)
+	}
+
+	resumed, table, err := held.Resume(strings.ToLower(order.TrackingCode))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if table != "4" || resumed.ID != order.ID || resumed.Status != StatusDraft {
+		t.Errorf("meja %s, ID %s, status %s", table, resumed.ID, resumed.Status)
+	}
+	if len(resumed.Items) != 3 || resumed.Items[0].Note != "tanpa bawang" || resumed.Items[1].Seat != 2 {
+		t.Errorf("item tidak utuh setelah dilanjutkan: %+v", resumed.Items)
+	}
+	if resumed.Discount == nil || resumed.Total != order.Total {
+		t.Errorf("total %v, ingin %v dengan diskon tetap ada", resumed.Total, order.Total)
+	}
+	// Field tambahan ikut kembali dan ditanya ulang tanpa menjadi ganda
+	if value, _ := resumed.customField("nomor kendaraan"); value != "B 1234 XY" {
+		t.Errorf("nomor kendaraan = %q setelah dilanjutkan", value)
+	}
+	resumed.setCustomField("nomor kendaraan", "B 5678 XY")
+	if len(resumed.CustomFields) != 1 || resumed.CustomFields[0].Value != "B 5678 XY" {
+		t.Errorf("field tambahan = %+v", resumed.CustomFields)
+	}
+
+	// Pesanan yang sudah dilanjutkan tidak bisa dilanjutkan lagi
+	if _, _, err := held.Resume(order.ID); err == nil {
+		t.Error("pesanan bisa dilanjutkan dua kali")
+	}
+	if list, _ := held.List(); len(list) != 0 {
+		t.Errorf("daftar tahanan masih berisi %d pesanan", len(list))
+	}
+}
+
+func TestResumeByID(t *testing.T) {
+	held := NewHeldOrders(filepath.Join(t.TempDir(), "ditahan.json"))
+	first, second := newTestOrder(), newTestOrder()
+	held.Hold("1", first)
+	held.Hold("2", second)
+
+	resumed, table, err := held.Resume(second.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resumed.TrackingCode != second.TrackingCode || table != "2" {
+		t.Errorf("dilanjutkan %s meja %s, ingin %s meja 2", resumed.TrackingCode, table, second.TrackingCode)
+	}
+	if list, _ := held.List(); len(list) != 1 || list[0].ID != first.ID {
+		t.Errorf("sisa tahanan = %+v", list)
+	}
+}
+
+func TestHoldRejects(t *testing.T) {
+	held := NewHeldOrders(filepath.Join(t.TempDir(), "ditahan.json"))
+	if err := held.Hold("1", NewOrder()); err == nil {
+		t.Error("pesanan kosong bisa ditahan")
+	}
+	confirmed := newTestOrder()
+	confirmed.Confirm()
+	if err := held.Hold("1", confirmed); err == nil {
+		t.Error("pesanan yang sudah dikonfirmasi bisa ditahan")
+	}
+	if _, _, err := held.Resume("XXX-XXX"); err == nil {
+		t.Error("kode yang tidak ada bisa dilanjutkan")
+	}
+}
+
+func TestHeldOrdersReserveStock(t *testing.T) {
+	stock := func(n int) *int { return &n }
+	live := NewInMemoryMenuRepository(map[string]MenuEntry{
+		"nasi goreng": {Name: "nasi goreng", Price: 25000, Category: "makanan", Stock: stock(3)},
+	})
+	held := NewHeldOrders(filepath.Join(t.TempDir(), "ditahan.json"))
+	if err := held.Hold("2", newTestOrder()); err != nil { // 2 nasi goreng
+		t.Fatal(err)
+	}
+
+	open, err := held.Orders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, _ := live.Get("nasi goreng")
+	if err := checkStock(open, live, entry, 2); err == nil || !strings.Contains(err.Error(), "tersisa 1") {
+		t.Errorf("error = %v, ingin stok pesanan ditahan ikut dihitung", err)
+	}
+}
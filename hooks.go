@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"TUGAS_2MKTI/events"
+)
+
+// HookPoint titik dalam alur pesanan tempat hook dijalankan
+type HookPoint string
+
+const (
+	HookOrderConfirmed   HookPoint = "order_confirmed"
+	HookPaymentCompleted HookPoint = "payment_completed"
+)
+
+// hookTimeout batas waktu satu perintah hook
+const hookTimeout = 10 * time.Second
+
+// hookFunc callback Go yang dijalankan pada titik hook
+type hookFunc func(point HookPoint, order *Order) error
+
+var (
+	hooksMu sync.RWMutex
+	// shellHooks perintah shell per titik hook, dibaca dari file -hooks
+	shellHooks = map[HookPoint][]string{}
+	// funcHooks callback Go per titik hook, didaftarkan lewat registerHook
+	funcHooks = map[HookPoint][]hookFunc{}
+)
+
+// registerHook mendaftarkan callback Go untuk titik hook tertentu. Callback ditulis
+// di package ini dan didaftarkan dari fungsi init; integrasi dari luar program
+// memakai perintah shell di file -hooks.
+func registerHook(point HookPoint, fn hookFunc) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	funcHooks[point] = append(funcHooks[point], fn)
+}
+
+// loadHooks membaca perintah shell hook dari file JSON, misalnya
+// {"payment_completed": ["./kirim-struk.sh"]}
+func loadHooks(path string) error {
+	if path == "" {
+		return nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gagal membaca hooks: %w", err)
+	}
+
+	var hooks map[HookPoint][]string
+	if err := json.Unmarshal(b, &hooks); err != nil {
+		return fmt.Errorf("format hooks tidak valid: %w", err)
+	}
+	for point := range hooks {
+		switch point {
+		case HookOrderConfirmed, HookPaymentCompleted:
+		default:
+			return fmt.Errorf("titik hook '%s' tidak dikenal", point)
+		}
+	}
+
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	shellHooks = hooks
+	return nil
+}
+
+// runHooks menjalankan semua hook pada titik tertentu. Perintah shell menerima
+// event berversi dari package events dalam format JSON lewat stdin. Hook yang gagal tidak menghentikan pesanan,
+// errornya dikembalikan agar bisa ditampilkan.
+func runHooks(point HookPoint, order *Order) []error {
+	hooksMu.RLock()
+	commands := shellHooks[point]
+	funcs := funcHooks[point]
+	hooksMu.RUnlock()
+
+	if len(commands) == 0 && len(funcs) == 0 {
+		return nil
+	}
+
+	var errs []error
+	for _, fn := range funcs {
+		if err := fn(point, order); err != nil {
+			errs = append(errs, fmt.Errorf("hook %s: %w", point, err))
+		}
+	}
+
+	if len(commands) == 0 {
+		return errs
+	}
+	payload, err := events.Encode(orderEvent(point, order), clock.Now())
+	if err != nil {
+		return append(errs, fmt.Errorf("hook %s: %w", point, err))
+	}
+	for _, command := range commands {
+		if err := runShellHook(point, command, payload); err != nil {
+			errs = append(errs, fmt.Errorf("hook %s '%s': %w", point, command, err))
+		}
+	}
+	return errs
+}
+
+// orderEvent mengubah pesanan menjadi event publik untuk titik hook tertentu
+func orderEvent(point HookPoint, order *Order) events.Event {
+	if point == HookPaymentCompleted {
+		return events.OrderPaidV1{
+			OrderID:      order.ID,
+			TrackingCode: order.TrackingCode,
+			QueueNumber:  order.QueueNumber,
+			Total:        order.Total,
+			Discount:     discountAmount(order),
+			Payment:      order.Payment,
+			Change:       order.Change,
+		}
+	}
+
+	return events.OrderCreatedV1{OrderID: order.ID, TrackingCode: order.TrackingCode, Items: lineItems(order), Total: order.Total}
+}
+
+// lineItems mengubah item pesanan menjadi baris item event publik
+func lineItems(order *Order) []events.LineItemV1 {
+	items := make([]events.LineItemV1, len(order.Items))
+	for i, item := range order.Items {
+		var mods []string
+		for _, mod := range item.Modifiers {
+			mods = append(mods, mod.Group+": "+mod.Option)
+		}
+		var amount float64
+		if item.Unit != "" {
+			amount = item.Quantity
+		}
+		var components []events.ComponentV1
+		for _, c := range item.Components {
+			components = append(components, events.ComponentV1{Name: c.Name, Quantity: c.Quantity})
+		}
+		items[i] = events.LineItemV1{
+			Name:       item.Name,
+			SKU:        item.SKU,
+			Category:   item.Category,
+			Quantity:   int(math.Ceil(item.Quantity)),
+			Unit:       item.Unit,
+			Amount:     amount,
+			UnitPrice:  item.UnitPrice(),
+			Total:      item.LineTotal(),
+			Seat:       item.Seat,
+			Modifiers:  mods,
+			Note:       item.Note,
+			Components: components,
+		}
+	}
+	return items
+}
+
+// runShellHook menjalankan satu perintah shell dengan payload di stdin
+func runShellHook(point HookPoint, command string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), "POS_HOOK="+string(point))
+	return cmd.Run()
+}
+
+// reportHookErrors menampilkan error hook tanpa menghentikan program
+func reportHookErrors(errs []error) {
+	for _, err := range errs {
+		fmt.Printf("Peringatan: %v\n", err)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Locale menyimpan format mata uang dan istilah pajak untuk suatu wilayah
+type Locale struct {
+	Code           string `json:"code"`
+	CurrencySymbol string `json:"currency_symbol"`
+	Decimals       int    `json:"decimals"`
+	ThousandSep    string `json:"thousand_sep"`
+	DecimalSep     string `json:"decimal_sep"`
+	TaxName        string `json:"tax_name"`
+}
+
+// locales berisi locale bawaan yang bisa dipilih lewat flag -locale
+var locales = map[string]Locale{
+	"id-ID": {Code: "id-ID", CurrencySymbol: "Rp", Decimals: 0, ThousandSep: ".", DecimalSep: ",", TaxName: "PPN"},
+	"en-US": {Code: "en-US", CurrencySymbol: "$", Decimals: 2, ThousandSep: ",", DecimalSep: ".", TaxName: "Sales Tax"},
+	"ms-MY": {Code: "ms-MY", CurrencySymbol: "RM", Decimals: 2, ThousandSep: ",", DecimalSep: ".", TaxName: "SST"},
+}
+
+// currentLocale adalah locale yang sedang dipakai program
+var currentLocale = locales["id-ID"]
+
+// loadLocale memilih locale bawaan berdasarkan kode, atau membaca dari file jika path diisi
+func loadLocale(code, path string) (Locale, error) {
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return Locale{}, fmt.Errorf("gagal membaca locale: %w", err)
+		}
+		var l Locale
+		if err := json.Unmarshal(b, &l); err != nil {
+			return Locale{}, fmt.Errorf("format locale tidak valid: %w", err)
+		}
+		if l.Decimals < 0 {
+			return Locale{}, fmt.Errorf("jumlah digit desimal tidak boleh negatif")
+		}
+		return l, nil
+	}
+
+	l, exists := locales[code]
+	if !exists {
+		return Locale{}, fmt.Errorf("locale '%s' tidak dikenal", code)
+	}
+	return l, nil
+}
+
+// Round membulatkan nominal ke jumlah digit desimal locale
+func (l Locale) Round(amount float64) float64 {
+	scale := math.Pow10(l.Decimals)
+	return math.Round(amount*scale) / scale
+}
+
+// FormatMoney memformat nominal sesuai simbol mata uang dan pemisah locale
+func (l Locale) FormatMoney(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	s := strconv.FormatFloat(l.Round(amount), 'f', l.Decimals, 64)
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.ThousandSep)
+		}
+		b.WriteRune(c)
+	}
+	if fracPart != "" {
+		b.WriteString(l.DecimalSep)
+		b.WriteString(fracPart)
+	}
+	return sign + l.CurrencySymbol + b.String()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"TUGAS_2MKTI/events"
+)
+
+// lowStockTimeout batas waktu satu pengiriman notifikasi stok menipis
+const lowStockTimeout = 5 * time.Second
+
+// crossedLowStock mengecek apakah stok baru saja turun melewati batas low_stock.
+// Notifikasi hanya dikirim saat batas terlewati, bukan di setiap pesanan
+// berikutnya, sehingga manajer tidak menerima pesan berulang.
+func crossedLowStock(entry MenuEntry, before, after int) bool {
+	return entry.LowStock != nil && before > *entry.LowStock && after <= *entry.LowStock
+}
+
+// notifyLowStock mengirim event stock.low ke webhook untuk setiap item.
+// Gagal mengirim tidak menghentikan pesanan, errornya dikembalikan untuk ditampilkan.
+func notifyLowStock(url string, entries []MenuEntry) []error {
+	if url == "" {
+		return nil
+	}
+	client := &http.Client{Timeout: lowStockTimeout}
+	var errs []error
+	for _, entry := range entries {
+		payload, err := events.Encode(events.StockLowV1{
+			Store:     storeID,
+			Item:      entry.Name,
+			SKU:       entry.SKU,
+			Remaining: *entry.Stock,
+			Threshold: *entry.LowStock,
+		}, clock.Now())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("notifikasi stok '%s': %w", entry.Name, err))
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			errs = append(errs, fmt.Errorf("notifikasi stok '%s': webhook membalas %s", entry.Name, resp.Status))
+		}
+	}
+	return errs
+}
@@ -2,20 +2,21 @@ package main
 
 import (
 	"bufio"
-	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
 // OrderProcessor interface untuk pemrosesan pesanan
 type OrderProcessor interface {
 	Process(order *Order) error
-	ValidateOrder(order *Order) error
+	ValidateOrder(order *Order) ([]ValidationWarning, error)
 }
 
 // DataValidator interface untuk validasi data
@@ -25,32 +26,50 @@ type DataValidator interface {
 
 // MenuItem merepresentasikan item dalam menu
 type MenuItem struct {
-	Name     string
-	Price    float64
-	Quantity int
+	Name      string
+	Price     float64
+	Quantity  float64 // boleh pecahan untuk item yang dijual per satuan
+	Unit      string  // satuan harga, misalnya "100g"; kosong berarti per item
+	Category  string
+	Seat      int // nomor kursi pada layanan penuh, 0 berarti item bersama
+	Modifiers []SelectedModifier
+	Note      string // catatan bebas untuk dapur, misalnya "tanpa bawang"
+	// Components isi paket untuk dapur; harga tetap dihitung dari Price paket
+	Components []BundleComponent
+
+	// Metadata tampilan, disalin dari menu
+	Description string
+	SKU         string
+	ImageURL    string
+
+	TaxClass string
 }
 
 // Order merepresentasikan pesanan
 type Order struct {
-	Items     []*MenuItem
-	Total     float64
-	Payment   float64
-	Change    float64
-	Encrypted string
-}
-
-// RestaurantOrderProcessor implementasi dari OrderProcessor
-type RestaurantOrderProcessor struct {
-	wg       sync.WaitGroup
-	orders   chan *Order
-	results  chan *Order
-	timeout  time.Duration
-}
+	ID           string // ID unik pesanan (ULID), stabil untuk riwayat dan laporan
+	CreatedAt    time.Time
+	PaidAt       time.Time
+	Status       OrderStatus
+	CancelReason CancelReason
+	CancelledAt  time.Time
+	TrackingCode string
+	QueueNumber  int
+	Items        []*MenuItem
+	Total        float64
+	Payment      float64
+	Change       float64
+	Encrypted    string
+	CustomFields []CustomFieldValue
+	WifiVoucher  string
+	MenuVersion  uint64         // versi menu yang dipakai untuk harga pesanan ini
+	Discount     *OrderDiscount // potongan untuk seluruh pesanan, nil jika tidak ada
+	Taxes        []TaxLine
+	Splits       []SplitBill // bagian tagihan jika dibayar beberapa orang
+	Training     bool        // pesanan latihan, tidak dicatat sebagai penjualan
 
-// menuList menyimpan daftar menu (unexported)
-var menuList = map[string]float64{
-	"nasi goreng": 25000,
-	"ayam bakar":  30000,
+	Warnings             []ValidationWarning
+	WarningsAcknowledged bool
 }
 
 // validateInput menggunakan regexp untuk validasi input
@@ -71,58 +90,122 @@ func validateInput(input interface{}) error {
 }
 
 func NewOrder() *Order {
+	now := clock.Now()
 	return &Order{
-		Items: make([]*MenuItem, 0),
+		ID:           newOrderID(now),
+		CreatedAt:    now,
+		Status:       StatusDraft,
+		TrackingCode: trackingCodes.Next(),
+		Items:        make([]*MenuItem, 0),
 	}
 }
 
 // AddItem menambahkan item ke pesanan menggunakan pointer
-func (o *Order) AddItem(name string, price float64, quantity int) {
+func (o *Order) AddItem(name string, price float64, quantity float64) {
+	o.AddItemForSeat(name, price, quantity, 0)
+}
+
+// AddItemForSeat menambahkan item ke pesanan untuk nomor kursi tertentu
+func (o *Order) AddItemForSeat(name string, price float64, quantity float64, seat int) *MenuItem {
 	item := &MenuItem{
 		Name:     name,
 		Price:    price,
 		Quantity: quantity,
+		Seat:     seat,
 	}
 	o.Items = append(o.Items, item)
 	o.calculateTotal()
+	return item
 }
 
-// calculateTotal menghitung total pesanan (unexported method)
-func (o *Order) calculateTotal() {
-	o.Total = 0
-	for _, item := range o.Items {
-		o.Total += item.Price * float64(item.Quantity)
+// RemoveItem menghapus item ke-index (dihitung dari 0) dari pesanan lalu menghitung ulang total
+func (o *Order) RemoveItem(index int) error {
+	if err := o.requireDraft(); err != nil {
+		return err
 	}
+	if index < 0 || index >= len(o.Items) {
+		return fmt.Errorf("item nomor %d tidak ada di pesanan", index+1)
+	}
+	o.Items = append(o.Items[:index], o.Items[index+1:]...)
+	o.calculateTotal()
+	return nil
 }
 
-// NewRestaurantOrderProcessor membuat processor baru
-func NewRestaurantOrderProcessor() *RestaurantOrderProcessor {
-	return &RestaurantOrderProcessor{
-		orders:   make(chan *Order, 10), // buffered channel
-		results:  make(chan *Order, 10),
-		timeout:  5 * time.Second,
+// UpdateQuantity mengubah jumlah item ke-index (dihitung dari 0) lalu menghitung ulang total
+func (o *Order) UpdateQuantity(index int, quantity float64) error {
+	if err := o.requireDraft(); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(o.Items) {
+		return fmt.Errorf("item nomor %d tidak ada di pesanan", index+1)
+	}
+	item := o.Items[index]
+	if quantity <= 0 {
+		return fmt.Errorf("jumlah '%s' harus lebih dari nol", item.Name)
 	}
+	if item.Unit == "" && quantity != math.Trunc(quantity) {
+		return fmt.Errorf("'%s' hanya bisa dipesan dalam jumlah bulat", item.Name)
+	}
+	item.Quantity = quantity
+	o.calculateTotal()
+	return nil
 }
 
-func (p *RestaurantOrderProcessor) ProcessOrder(order *Order) {
-	p.wg.Add(1)
-	go func() {
-		defer p.wg.Done()
-		
-		select {
-		case <-time.After(p.timeout):
-			fmt.Println("Timeout processing order")
-		case p.orders <- order:
-			// Enkripsi dan proses pesanan
-			orderDetails := fmt.Sprintf("Total: %.2f, Payment: %.2f, Change: %.2f", 
-				order.Total, order.Payment, order.Change)
-			order.Encrypted = base64.StdEncoding.EncodeToString([]byte(orderDetails))
-			p.results <- order
-		}
-	}()
+// calculateTotal menghitung total pesanan dengan pricing engine aktif lalu
+// menambahkan pajak per kelas (unexported method)
+func (o *Order) calculateTotal() {
+	subtotal := pricingEngineFor(o).Price(o).Total
+	o.Total = subtotal
+	if o.Discount != nil {
+		o.Discount.Amount = o.Discount.amountFor(subtotal)
+		o.Total -= o.Discount.Amount
+	}
+	o.Taxes = computeTaxes(o, taxRates, subtotal)
+	for _, tax := range o.Taxes {
+		o.Total += tax.Amount
+	}
 }
 
 func main() {
+	// Perintah "doctor" memeriksa konfigurasi tanpa masuk ke mode kasir
+	if len(os.Args) > 1 && os.Args[1] == "doctor" {
+		cfg, err := parseConfig(os.Args[2:])
+		if err != nil {
+			os.Exit(2)
+		}
+		if !runDoctor(cfg, os.Stdout) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Perintah "menu export|import" untuk mengedit menu lewat spreadsheet
+	if len(os.Args) > 1 && os.Args[1] == "menu" {
+		if err := runMenuCommand(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Perintah "serve" menjalankan server HTTP menu publik
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := runServe(os.Args[2:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Perintah "pipeline" menampilkan tahap pemrosesan pesanan sesuai konfigurasi
+	if len(os.Args) > 1 && os.Args[1] == "pipeline" {
+		if err := runPipeline(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Defer untuk memastikan pesan "Program selesai" selalu dicetak
 	defer func() {
 		fmt.Println("\nMenggunakan bantuan di gnulinux lab...")
@@ -136,82 +219,719 @@ func main() {
 		}
 	}()
 
-	processor := NewRestaurantOrderProcessor()
 	reader := bufio.NewReader(os.Stdin)
-	order := NewOrder()
 
-	for {
-		fmt.Println("\nMenu:")
-		for name, price := range menuList {
-			fmt.Printf("- %s: Rp%.2f\n", strings.Title(name), price)
+	// Peluncuran pertama tanpa konfigurasi: jalankan panduan penyiapan
+	if len(os.Args) == 1 && needsOnboarding() {
+		if err := runOnboarding(reader, os.Stdout); errors.Is(err, io.EOF) {
+			fmt.Println(inputEndedMessage)
+			return
+		} else if err != nil {
+			panic(err)
 		}
-		fmt.Printf("\nMasukkan nama item [ketik 'selesai' untuk menyelesaikan]\n")
+	}
 
-		fmt.Print("Pilihan: ")
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(strings.ToLower(input))
+	cfg, err := parseConfig(os.Args[1:])
+	if err != nil {
+		panic(err)
+	}
 
-		if input == "selesai" {
-			break
-		}
+	if err := SetPricingEngine(cfg.PricingEngine); err != nil {
+		panic(err)
+	}
+
+	roles, err = loadRoles(cfg.RolesPath)
+	if err != nil {
+		panic(err)
+	}
+	if err := setRole(cfg.Role); err != nil {
+		panic(err)
+	}
 
-		// Validasi input menggunakan interface kosong dan type assertion
-		if err := validateInput(interface{}(input)); err != nil {
+	storeID = cfg.StoreID
+	if err := loadFeatureFlags(cfg.FlagsPath); err != nil {
+		panic(err)
+	}
+
+	currentLocale, err = loadLocale(cfg.LocaleCode, cfg.LocalePath)
+	if err != nil {
+		panic(err)
+	}
+
+	// Mode latihan memakai salinan data agar data asli tidak tersentuh.
+	// Dipasang sebelum menu pusat diambil agar sinkronisasi menulis ke salinan latihan.
+	if cfg.Training {
+		if err := applyTrainingMode(cfg); err != nil {
 			panic(err)
 		}
+	}
 
-		price, exists := menuList[input]
-		if !exists {
-			panic(fmt.Sprintf("Menu '%s' tidak tersedia", input))
+	var remoteMenu *RemoteMenu
+	if cfg.MenuURL != "" {
+		cachePath := cfg.MenuPath
+		if cachePath == "" {
+			cachePath = defaultMenuPath
+		}
+		remoteMenu = NewRemoteMenu(cfg.MenuURL, cachePath)
+		if err := remoteMenu.Sync(); err != nil {
+			if _, statErr := os.Stat(cachePath); statErr != nil {
+				panic(err)
+			}
+			fmt.Printf("Peringatan: %v, memakai menu cache %s\n", err, cachePath)
 		}
+		cfg.MenuPath = cachePath
+	}
 
-		fmt.Print("Masukkan jumlah: ")
-		qtyStr, _ := reader.ReadString('\n')
-		qtyStr = strings.TrimSpace(qtyStr)
-		qty, err := strconv.Atoi(qtyStr)
+	menuStore, err := openMenuRepository(cfg.MenuPath)
+	if err != nil {
+		panic(err)
+	}
+	if remoteMenu != nil {
+		stopRemote := remoteMenu.Start(menuStore, cfg.MenuRefresh, func(err error) {
+			if err != nil {
+				fmt.Printf("\nPeringatan: %v\n", err)
+				return
+			}
+			fmt.Println("\nMenu pusat diperbarui, berlaku untuk pesanan berikutnya")
+		})
+		defer stopRemote()
+	}
+	stopWatch := menuStore.Watch(menuReloadInterval, func(err error) {
 		if err != nil {
-			panic("Jumlah tidak valid")
+			fmt.Printf("\nPeringatan: menu baru tidak dipakai: %v\n", err)
+			return
 		}
+		fmt.Println("\nMenu dimuat ulang dari file, berlaku untuk pesanan berikutnya")
+	})
+	defer stopWatch()
+
+	if err := loadPricingRules(cfg.PricingRulesPath); err != nil {
+		panic(err)
+	}
+
+	taxRates, err = loadTaxRates(cfg.TaxRatesPath)
+	if err != nil {
+		panic(err)
+	}
+	if err := validateTaxClasses(menuStore.List(), taxRates); err != nil {
+		panic(err)
+	}
+
+	receiptTmpl, err := loadReceiptTemplate(cfg.ReceiptTemplatePath, cfg.Plain)
+	if err != nil {
+		panic(err)
+	}
+
+	customFields, err := loadCustomFields(cfg.CustomFieldsPath)
+	if err != nil {
+		panic(err)
+	}
+
+	plainOutput = cfg.Plain
+	discountCap = cfg.DiscountCap
+	discountApprovalPercent = cfg.DiscountApprovalPercent
+
+	if err := loadHooks(cfg.HooksPath); err != nil {
+		panic(err)
+	}
+
+	display, err := openDisplay(cfg.DisplayTarget)
+	if err != nil {
+		panic(err)
+	}
+	defer display.Close()
 
-		order.AddItem(strings.Title(input), price, qty)
+	var wifiVouchers *WifiVoucherPool
+	if cfg.WifiVoucherPath != "" {
+		wifiVouchers = NewWifiVoucherPool(cfg.WifiVoucherPath)
 	}
 
-	// Menampilkan pesanan
-	fmt.Println("\nPesanan Anda:")
-	for _, item := range order.Items {
-		fmt.Printf("- %s (x%d)\n", item.Name, item.Quantity)
+	processor := NewRestaurantOrderProcessor()
+	defer processor.Close()
+
+	// Satu pembagi nomor antrian untuk seluruh sesi, sehingga kuncinya berlaku untuk semua pesanan
+	var queueNumbers *QueueNumbers
+	if cfg.QueueFile != "" {
+		queueNumbers = NewQueueNumbers(cfg.QueueFile)
 	}
-	fmt.Printf("Total Harga: Rp%.2f\n", order.Total)
 
-	// Memproses pembayaran
-	fmt.Print("\nMasukkan jumlah uang: ")
-	paymentStr, _ := reader.ReadString('\n')
-	paymentStr = strings.TrimSpace(paymentStr)
-	payment, err := strconv.ParseFloat(paymentStr, 64)
+	// Kode pesanan yang ditahan dari sesi sebelumnya tidak boleh diberikan ke pesanan baru,
+	// karena pesanan ditahan bisa dilanjutkan lewat kodenya
+	heldOrders := NewHeldOrders(cfg.HeldOrdersPath)
+	held, err := heldOrders.List()
 	if err != nil {
-		panic("Jumlah pembayaran tidak valid")
+		panic(err)
+	}
+	for _, record := range held {
+		trackingCodes.Reserve(record.TrackingCode)
 	}
 
-	if payment < order.Total {
-		panic("Pembayaran kurang!")
+	// Setiap meja punya pesanan sendiri; sesi dimulai dengan meja 1
+	tickets := NewOrderManager(func() *Ticket {
+		order := NewOrder()
+		order.Training = cfg.Training
+		// Pesanan memakai salinan menu saat pesanan dimulai, sehingga menu yang
+		// dimuat ulang dari file tidak mengubah harga di tengah pesanan
+		menu := menuStore.Snapshot()
+		order.MenuVersion = menu.Version()
+		return &Ticket{Order: order, Menu: menu}
+	})
+	ticket, _, _ := tickets.Open("1")
+	// openOrders pesanan terbuka di semua meja ditambah pesanan yang ditahan,
+	// karena keduanya sudah memesan stok yang belum dipotong
+	openOrders := func() []*Order {
+		orders := tickets.Orders()
+		heldDrafts, err := heldOrders.Orders()
+		if err != nil {
+			fmt.Printf("Peringatan: stok pesanan ditahan tidak dihitung: %v\n", err)
+		}
+		return append(orders, heldDrafts...)
 	}
+	cancelLog := NewCancelLog(cfg.CancelLogPath)
+	menuPage := 0
+
+	// Satu putaran per pesanan yang dibayar; sesi selesai jika tidak ada meja yang masih terbuka
+	for {
+		order, menu := ticket.Order, ticket.Menu
+
+		// Pembayaran bisa sudah diisi lewat input cepat
+		var payment float64
+		paid := false
+
+		for {
+			if order.Training {
+				fmt.Println()
+				printTrainingBanner(os.Stdout)
+			}
+			menuPage = printMenuPage(os.Stdout, availableMenu(menu.List(), clock.Now()), pricingFor(menu), menuCategoryFilter, menuPage, cfg.PageSize)
+			fmt.Printf("\nMasukkan nama item [ketik 'selesai' untuk menyelesaikan]\n")
+			fmt.Printf("Format cepat: 2x nasi goreng; 1x ayam bakar; bayar 100000\n")
+			fmt.Printf("Ketik '?' untuk mencari perintah lain\n")
+
+			if len(tickets.List()) > 1 {
+				fmt.Printf("Pilihan (meja %s): ", ticket.Table)
+			} else {
+				fmt.Print("Pilihan: ")
+			}
+			input, err := readLine(reader)
+			if err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+			input = strings.ToLower(input)
+
+			if input == "selesai" {
+				break
+			}
+
+			switch input {
+			case "berikut":
+				menuPage++
+				continue
+			case "sebelum":
+				menuPage--
+				continue
+			}
+
+			if input == "meja" {
+				printTickets(os.Stdout, tickets.List(), ticket.Table)
+				continue
+			}
+			if strings.HasPrefix(input, "meja ") {
+				next, created, err := tickets.Open(strings.TrimSpace(strings.TrimPrefix(input, "meja ")))
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				ticket = next
+				order, menu = ticket.Order, ticket.Menu
+				menuPage = 0
+				if created {
+					fmt.Printf("Pesanan baru dibuka untuk meja %s\n", ticket.Table)
+				} else {
+					fmt.Printf("Pindah ke meja %s\n", ticket.Table)
+				}
+				continue
+			}
+
+			if input == "tahan" {
+				if err := heldOrders.Hold(ticket.Table, order); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				// Tetap dicadangkan walaupun pesanan baru dilanjutkan setelah hari berganti
+				trackingCodes.Reserve(order.TrackingCode)
+				fmt.Printf("Pesanan %s ditahan, lanjutkan nanti dengan 'lanjut %s'\n", order.TrackingCode, order.TrackingCode)
+				// Meja yang sama mendapat pesanan baru yang kosong
+				if err := tickets.Close(ticket.Table); err != nil {
+					panic(err)
+				}
+				ticket, _, _ = tickets.Open(ticket.Table)
+				order, menu = ticket.Order, ticket.Menu
+				continue
+			}
+			if input == "diskon" || strings.HasPrefix(input, "diskon ") {
+				if err := runDiscountCommand(input, order); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				printOrderSummary(os.Stdout, order)
+				continue
+			}
+			if input == "batal" {
+				printCancelReasons(os.Stdout)
+				continue
+			}
+			if strings.HasPrefix(input, "batal ") {
+				reason, err := parseCancelReason(strings.TrimSpace(strings.TrimPrefix(input, "batal ")))
+				if err == nil {
+					err = cancelOrder(order, reason, menuStore, cancelLog)
+				}
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				fmt.Printf("Pesanan %s dibatalkan (%s)\n", order.TrackingCode, reason)
+				// Meja yang sama mendapat pesanan baru yang kosong
+				if err := tickets.Close(ticket.Table); err != nil {
+					panic(err)
+				}
+				ticket, _, _ = tickets.Open(ticket.Table)
+				order, menu = ticket.Order, ticket.Menu
+				continue
+			}
+			if input == "ditahan" {
+				held, err := heldOrders.List()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				printHeldOrders(os.Stdout, held)
+				continue
+			}
+			if strings.HasPrefix(input, "lanjut ") {
+				if len(order.Items) > 0 {
+					fmt.Printf("Error: meja %s masih punya pesanan, pindah ke meja kosong dengan 'meja <nomor>'\n", ticket.Table)
+					continue
+				}
+				resumed, table, err := heldOrders.Resume(strings.TrimSpace(strings.TrimPrefix(input, "lanjut ")))
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				// Item baru memakai menu saat ini; harga item lama tetap seperti saat ditahan
+				menu = menuStore.Snapshot()
+				resumed.MenuVersion = menu.Version()
+				ticket.Order, ticket.Menu = resumed, menu
+				order = resumed
+				fmt.Printf("Pesanan %s dari meja %s dilanjutkan di meja %s\n", order.TrackingCode, table, ticket.Table)
+				printOrderSummary(os.Stdout, order)
+				continue
+			}
+
+			if strings.HasPrefix(input, "gabung ") {
+				table := strings.TrimSpace(strings.TrimPrefix(input, "gabung "))
+				other, exists := tickets.Get(table)
+				if !exists {
+					fmt.Printf("Error: tidak ada pesanan terbuka di meja %s\n", table)
+					continue
+				}
+				if err := order.Merge(other.Order); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				if err := tickets.Close(other.Table); err != nil {
+					panic(err)
+				}
+				fmt.Printf("Pesanan meja %s digabung ke meja %s\n", other.Table, ticket.Table)
+				printOrderSummary(os.Stdout, order)
+				continue
+			}
+
+			if input == "admin" {
+				if err := requirePermission(PermEditMenu); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				runMenuAdmin(reader, menuStore)
+				// Perubahan dari mode admin sengaja dipakai langsung oleh pesanan ini
+				menu = menuStore.Snapshot()
+				order.MenuVersion = menu.Version()
+				ticket.Menu = menu
+				continue
+			}
+
+			if input == "fitur" || strings.HasPrefix(input, "fitur ") {
+				if input != "fitur" {
+					if err := requirePermission(PermManageFeatures); err != nil {
+						fmt.Printf("Error: %v\n", err)
+						continue
+					}
+				}
+				if err := runFeatureCommand(input); err != nil {
+					fmt.Printf("Error: %v\n", err)
+				}
+				continue
+			}
 
-	order.Payment = payment
-	order.Change = payment - order.Total
+			if input == "kategori" || strings.HasPrefix(input, "kategori ") {
+				if err := runCategoryCommand(input, menu); err != nil {
+					fmt.Printf("Error: %v\n", err)
+				}
+				menuPage = 0
+				continue
+			}
 
-	// Proses pesanan menggunakan goroutine
-	processor.ProcessOrder(order)
+			if strings.HasPrefix(input, "hapus ") || strings.HasPrefix(input, "ubah ") || strings.HasPrefix(input, "catatan ") {
+				if err := runOrderEditCommand(input, order, menuStore, openOrders()); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				printOrderSummary(os.Stdout, order)
+				continue
+			}
 
-	// Tunggu semua goroutine selesai
-	processor.wg.Wait()
-	close(processor.orders)
-	close(processor.results)
+			if input == "tag" || strings.HasPrefix(input, "tag ") {
+				if err := runTagCommand(os.Stdout, input, availableMenu(menu.List(), clock.Now()), pricingFor(menu)); err != nil {
+					fmt.Printf("Error: %v\n", err)
+				}
+				continue
+			}
 
-	// Ambil hasil proses
-	processedOrder := <-processor.results
+			if input == "pipeline" {
+				printPipeline(os.Stdout, describePipeline(cfg, processor), plainOutput)
+				printPipelineStats(os.Stdout, processor, plainOutput)
+				continue
+			}
 
-	// Menampilkan hasil akhir
-	fmt.Printf("\nUang yang dibayar: Rp%.2f\n", processedOrder.Payment)
-	fmt.Printf("Kembalian: Rp%.2f\n", processedOrder.Change)
-	fmt.Printf("Pesanan (encoded format): %s\n", processedOrder.Encrypted)
-}
\ No newline at end of file
+			if input == "wifi" {
+				if wifiVouchers == nil {
+					fmt.Println("Voucher Wi-Fi tidak dikonfigurasi")
+					continue
+				}
+				available, issued, err := wifiVouchers.Status()
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				fmt.Printf("Voucher Wi-Fi: %d tersedia, %d sudah diberikan\n", available, issued)
+				continue
+			}
+
+			if isPaletteQuery(input) {
+				showPalette(input)
+				continue
+			}
+
+			if err := requirePermission(PermTakeOrder); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+
+			// Input cepat untuk kasir: beberapa item dan pembayaran dalam satu baris
+			if isQuickEntry(input) {
+				entry, err := parseQuickEntry(input, menu)
+				if err == nil {
+					err = checkQuickEntryStock(openOrders(), menu, menuStore, entry)
+				}
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				for _, item := range entry.Items {
+					menuItem, _ := menu.Get(item.Name)
+					order.addMenuEntry(menu, menuItem, item.Quantity, 0)
+					showLastLine(display, order)
+				}
+				if entry.HasPayment {
+					payment = entry.Payment
+					paid = true
+					break
+				}
+				continue
+			}
+
+			// Validasi input menggunakan interface kosong dan type assertion
+			if err := validateInput(interface{}(input)); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+
+			entry, exists := lookupMenuItem(menu, input)
+			if !exists {
+				suggestion, found := suggestMenuItem(input, menu)
+				if !found {
+					fmt.Printf("Error: Menu '%s' tidak tersedia\n", input)
+					continue
+				}
+				fmt.Printf("Maksud Anda '%s'? (y/n): ", strings.Title(suggestion.Name))
+				answer, err := readLine(reader)
+				if err != nil {
+					fmt.Println(inputEndedMessage)
+					return
+				}
+				if strings.ToLower(answer) != "y" {
+					continue
+				}
+				entry = suggestion
+			}
+			if err := checkAvailable(entry); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+
+			fmt.Print("Masukkan jumlah: ")
+			qtyStr, err := readLine(reader)
+			if err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+			qty, err := parseQuantity(qtyStr)
+			if err != nil {
+				fmt.Println("Error: Jumlah tidak valid")
+				continue
+			}
+			if err := validateQuantity(entry, qty); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			// Stok dibaca dari menu live, snapshot pesanan hanya dipakai untuk harga
+			if err := checkStock(openOrders(), menuStore, entry, qty); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+
+			seat := 0
+			if cfg.FullService {
+				fmt.Print("Nomor kursi [kosongkan untuk item bersama]: ")
+				seatStr, err := readLine(reader)
+				if err != nil {
+					fmt.Println(inputEndedMessage)
+					return
+				}
+				if seatStr != "" {
+					seat, err = strconv.Atoi(seatStr)
+					if err != nil || seat < 0 {
+						fmt.Println("Error: Nomor kursi tidak valid")
+						continue
+					}
+				}
+			}
+
+			mods, err := promptModifiers(reader, entry)
+			if err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+			order.addMenuEntry(menu, entry, qty, seat, mods...)
+			if order.Items[len(order.Items)-1].Note, err = promptNote(reader); err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+			showLastLine(display, order)
+		}
+
+		// Menampilkan pesanan
+		printOrderSummary(os.Stdout, order)
+		reportDisplayError(display.ShowTotal(order.Total))
+		if cfg.FullService {
+			printSeatBills(os.Stdout, order)
+		}
+
+		// Field tambahan dari konfigurasi, misalnya nomor kendaraan
+		if len(customFields) > 0 {
+			fmt.Println("\nData tambahan:")
+			if err := promptCustomFields(reader, customFields, order); err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+		}
+
+		// Validasi pesanan: error menolak pesanan, peringatan harus dikonfirmasi kasir
+		// Kembali ke input item pada meja yang sama agar kasir bisa memperbaiki pesanan
+		warnings, err := processor.ValidateOrder(order)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		if len(warnings) > 0 {
+			printWarnings(os.Stdout, warnings)
+			fmt.Print("Lanjutkan pesanan? (y/n): ")
+			answer, err := readLine(reader)
+			if err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+			if strings.ToLower(answer) != "y" {
+				if err := cancelOrder(order, ReasonDeclined, menuStore, cancelLog); err != nil {
+					fmt.Printf("Error: %v\n", err)
+				}
+				fmt.Printf("Pesanan %s dibatalkan (%s)\n", order.TrackingCode, ReasonDeclined)
+				// Meja yang sama mendapat pesanan baru yang kosong
+				if err := tickets.Close(ticket.Table); err != nil {
+					panic(err)
+				}
+				ticket, _, _ = tickets.Open(ticket.Table)
+				continue
+			}
+			order.AcknowledgeWarnings()
+		}
+		// Stok dipotong sebelum konfirmasi: jika stok live sudah tidak cukup,
+		// pesanan tetap draf dan kasir kembali ke input item
+		lowStock, err := commitStock(order, menuStore)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		if err := order.Confirm(); err != nil {
+			panic(err)
+		}
+		reportHookErrors(notifyLowStock(cfg.LowStockWebhook, lowStock))
+		reportHookErrors(runHooks(HookOrderConfirmed, order))
+
+		// Memproses pembayaran
+		voided := false
+		for !paid {
+			fmt.Print("\nMasukkan jumlah uang [atau 'bagi 3' / 'bagi 1,2/3' untuk bagi tagihan, 'batal <kode>' untuk membatalkan]: ")
+			paymentStr, err := readLine(reader)
+			if err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+			paymentStr = strings.ToLower(paymentStr)
+
+			// Pesanan yang sudah dikonfirmasi masih bisa dibatalkan kasir sebelum dibayar,
+			// stok yang sudah dipotong dikembalikan oleh cancelOrder
+			if paymentStr == "batal" {
+				printCancelReasons(os.Stdout)
+				continue
+			}
+			if strings.HasPrefix(paymentStr, "batal ") {
+				reason, err := parseCancelReason(strings.TrimSpace(strings.TrimPrefix(paymentStr, "batal ")))
+				if err == nil {
+					err = cancelOrder(order, reason, menuStore, cancelLog)
+				}
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				fmt.Printf("Pesanan %s dibatalkan (%s)\n", order.TrackingCode, reason)
+				voided = true
+				break
+			}
+
+			// Bagi tagihan: setiap pembayar membayar bagiannya sendiri
+			if strings.HasPrefix(paymentStr, "bagi") {
+				bills, err := parseSplitCommand(paymentStr, order)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					continue
+				}
+				printSplitBills(os.Stdout, bills)
+				if err := promptSplitPayments(reader, bills); err != nil {
+					fmt.Println(inputEndedMessage)
+					return
+				}
+				order.Splits = bills
+				// Kembalian pesanan adalah jumlah kembalian tiap pembayar
+				payment = order.Total
+				for _, bill := range bills {
+					payment += bill.Change
+				}
+				break
+			}
+
+			payment, err = strconv.ParseFloat(paymentStr, 64)
+			if err != nil {
+				fmt.Println("Error: Jumlah pembayaran tidak valid")
+				continue
+			}
+			paid = true
+		}
+		if voided {
+			// Meja yang sama mendapat pesanan baru yang kosong
+			if err := tickets.Close(ticket.Table); err != nil {
+				panic(err)
+			}
+			ticket, _, _ = tickets.Open(ticket.Table)
+			continue
+		}
+
+		for payment < order.Total {
+			// Pembayaran kurang, termasuk dari input cepat: tanyakan ulang jumlahnya
+			fmt.Printf("Error: Pembayaran kurang %s\n", currentLocale.FormatMoney(order.Total-payment))
+			fmt.Print("Masukkan jumlah uang: ")
+			amountStr, err := readLine(reader)
+			if err != nil {
+				fmt.Println(inputEndedMessage)
+				return
+			}
+			amount, err := strconv.ParseFloat(amountStr, 64)
+			if err != nil {
+				fmt.Println("Error: Jumlah pembayaran tidak valid")
+				continue
+			}
+			payment = amount
+		}
+
+		order.Payment = payment
+		order.Change = payment - order.Total
+		order.PaidAt = clock.Now()
+		if err := order.MarkPaid(); err != nil {
+			panic(err)
+		}
+		reportDisplayError(display.ShowChange(order.Payment, order.Change))
+
+		// Cetak slip nomor antrian segera setelah pesanan dibayar
+		if queueNumbers != nil {
+			order.QueueNumber, err = queueNumbers.Next()
+			if err != nil {
+				panic(err)
+			}
+			printQueueSlip(os.Stdout, order)
+		}
+
+		// Proses pesanan menggunakan goroutine
+		handle, err := processor.ProcessOrder(order)
+		if err != nil {
+			panic(err)
+		}
+
+		// Ambil hasil proses, lalu tunggu semua goroutine selesai
+		processedOrder, err := handle.Result()
+		if err != nil {
+			panic(err)
+		}
+
+		// Voucher Wi-Fi tamu dicetak di struk jika pool dikonfigurasi
+		if wifiVouchers != nil {
+			processedOrder.WifiVoucher, err = wifiVouchers.Issue(processedOrder)
+			if err != nil {
+				fmt.Printf("Peringatan: %v\n", err)
+			}
+		}
+
+		// Menampilkan hasil akhir menggunakan template struk. Tanda latihan dicetak di luar
+		// template agar tetap muncul walaupun template kustom tidak mengenalnya.
+		if processedOrder.Training {
+			printTrainingBanner(os.Stdout)
+		}
+		if err := receiptTmpl.Execute(os.Stdout, processedOrder); err != nil {
+			panic(err)
+		}
+		if processedOrder.Training {
+			printTrainingBanner(os.Stdout)
+		}
+		reportHookErrors(runHooks(HookPaymentCompleted, processedOrder))
+
+		if err := tickets.Close(ticket.Table); err != nil {
+			panic(err)
+		}
+		open := tickets.List()
+		if len(open) == 0 {
+			break
+		}
+		ticket = open[0]
+		fmt.Printf("\nMasih ada %d meja terbuka, lanjut ke meja %s\n", len(open), ticket.Table)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// newTestOrder pesanan draf berisi Nasi Goreng x2, Ayam Bakar x1 dan Es Teh x3
+func newTestOrder() *Order {
+	order := NewOrder()
+	order.AddItem("Nasi Goreng", 25000, 2)
+	order.AddItem("Ayam Bakar", 30000, 1)
+	order.AddItem("Es Teh", 5000, 3)
+	return order
+}
+
+func TestRemoveItem(t *testing.T) {
+	tests := []struct {
+		name      string
+		index     int
+		wantItems []string
+		wantTotal float64
+		wantErr   string
+	}{
+		{name: "item pertama", index: 0, wantItems: []string{"Ayam Bakar", "Es Teh"}, wantTotal: 45000},
+		{name: "item tengah", index: 1, wantItems: []string{"Nasi Goreng", "Es Teh"}, wantTotal: 65000},
+		{name: "item terakhir", index: 2, wantItems: []string{"Nasi Goreng", "Ayam Bakar"}, wantTotal: 80000},
+		{name: "nomor negatif", index: -1, wantErr: "item nomor 0 tidak ada"},
+		{name: "nomor di luar pesanan", index: 3, wantErr: "item nomor 4 tidak ada"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newTestOrder()
+			err := order.RemoveItem(tt.index)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, ingin mengandung %q", err, tt.wantErr)
+				}
+				if len(order.Items) != 3 || order.Total != 95000 {
+					t.Errorf("pesanan berubah setelah error: %d item, total %v", len(order.Items), order.Total)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, item := range order.Items {
+				names = append(names, item.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantItems, ",") {
+				t.Errorf("item = %v, ingin %v", names, tt.wantItems)
+			}
+			if order.Total != tt.wantTotal {
+				t.Errorf("total = %v, ingin %v", order.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestUpdateQuantity(t *testing.T) {
+	tests := []struct {
+		name      string
+		index     int
+		quantity  float64
+		unit      string
+		wantTotal float64
+		wantErr   string
+	}{
+		{name: "tambah jumlah", index: 0, quantity: 4, wantTotal: 145000},
+		{name: "kurangi jumlah", index: 2, quantity: 1, wantTotal: 85000},
+		{name: "jumlah nol", index: 0, quantity: 0, wantErr: "harus lebih dari nol"},
+		{name: "jumlah negatif", index: 1, quantity: -2, wantErr: "harus lebih dari nol"},
+		{name: "pecahan tanpa satuan", index: 1, quantity: 1.5, wantErr: "jumlah bulat"},
+		{name: "pecahan dengan satuan", index: 1, quantity: 1.5, unit: "porsi", wantTotal: 110000},
+		{name: "nomor di luar pesanan", index: 5, quantity: 1, wantErr: "item nomor 6 tidak ada"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newTestOrder()
+			if tt.unit != "" {
+				order.Items[tt.index].Unit = tt.unit
+			}
+			err := order.UpdateQuantity(tt.index, tt.quantity)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, ingin mengandung %q", err, tt.wantErr)
+				}
+				if order.Total != 95000 {
+					t.Errorf("total berubah setelah error: %v", order.Total)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if order.Items[tt.index].Quantity != tt.quantity {
+				t.Errorf("jumlah = %v, ingin %v", order.Items[tt.index].Quantity, tt.quantity)
+			}
+			if order.Total != tt.wantTotal {
+				t.Errorf("total = %v, ingin %v", order.Total, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestEditRequiresDraft(t *testing.T) {
+	order := newTestOrder()
+	if err := order.Confirm(); err != nil {
+		t.Fatal(err)
+	}
+	if err := order.RemoveItem(0); err == nil {
+		t.Error("RemoveItem berhasil pada pesanan yang sudah dikonfirmasi")
+	}
+	if err := order.UpdateQuantity(0, 1); err == nil {
+		t.Error("UpdateQuantity berhasil pada pesanan yang sudah dikonfirmasi")
+	}
+	if len(order.Items) != 3 || order.Items[0].Quantity != 2 {
+		t.Error("item pesanan yang sudah dikonfirmasi berubah")
+	}
+}
+
+func TestReadLineEOF(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantEOF bool
+	}{
+		{"baris lengkap", "nasi goreng\n", "nasi goreng", false},
+		{"baris terakhir tanpa newline", "selesai", "selesai", false},
+		{"input habis", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readLine(bufio.NewReader(strings.NewReader(tt.input)))
+			if errors.Is(err, io.EOF) != tt.wantEOF {
+				t.Fatalf("error = %v, ingin EOF %v", err, tt.wantEOF)
+			}
+			if got != tt.want {
+				t.Errorf("baris = %q, ingin %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := promptNote(bufio.NewReader(strings.NewReader(""))); !errors.Is(err, io.EOF) {
+		t.Errorf("promptNote error = %v, ingin io.EOF", err)
+	}
+}
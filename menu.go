@@ -0,0 +1,263 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// defaultMenuPath file menu yang dibaca otomatis saat program mulai jika ada
+const defaultMenuPath = "menu.json"
+
+// MenuEntry merepresentasikan satu item pada file menu
+type MenuEntry struct {
+	Name      string               `json:"name"`
+	Price     float64              `json:"price"`
+	Category  string               `json:"category"`
+	Modifiers []ModifierGroup      `json:"modifiers,omitempty"`
+	Available []AvailabilityWindow `json:"available,omitempty"`
+	Stock     *int                 `json:"stock,omitempty"`     // nil berarti stok tidak dilacak
+	LowStock  *int                 `json:"low_stock,omitempty"` // batas stok menipis untuk notifikasi manajer
+	Bundle    []BundleComponent    `json:"bundle,omitempty"`    // isi paket; harga paket tetap satu baris
+	// Masa berlaku musiman, format YYYY-MM-DD dan inklusif; kosong berarti tanpa batas
+	ValidFrom  string `json:"valid_from,omitempty"`
+	ValidUntil string `json:"valid_until,omitempty"`
+
+	Description string `json:"description,omitempty"`
+	SKU         string `json:"sku,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	TaxClass    string `json:"tax_class,omitempty"` // kosong berarti kelas pajak standar
+
+	// Unit satuan harga untuk item yang dijual per berat/volume, misalnya "100g"
+	// atau "porsi"; item dengan satuan boleh dipesan dalam jumlah pecahan
+	Unit string `json:"unit,omitempty"`
+
+	// Tags label alergen dan diet, misalnya "halal", "vegetarian", "kacang"
+	Tags []string `json:"tags,omitempty"`
+
+	// Aliases nama lain yang diterima saat input, misalnya "nasgor" untuk "nasi goreng"
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// resolveMenuPath menentukan file menu yang dipakai: path dari flag, menu.json
+// di direktori kerja jika ada, atau kosong untuk menu bawaan
+func resolveMenuPath(path string) string {
+	if path != "" {
+		return path
+	}
+	if _, err := os.Stat(defaultMenuPath); err == nil {
+		return defaultMenuPath
+	}
+	return ""
+}
+
+// loadMenu membaca menu dari path, atau menu bawaan jika path kosong
+func loadMenu(path string) (map[string]MenuEntry, error) {
+	data, source := defaultMenu, "menu bawaan"
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("gagal membaca menu: %w", err)
+		}
+		data, source = b, path
+	}
+
+	menu, err := parseMenu(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return menu, nil
+}
+
+// parseMenu mem-parsing dan memvalidasi isi file menu
+func parseMenu(data []byte) (map[string]MenuEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var entries []MenuEntry
+	if err := dec.Decode(&entries); err != nil {
+		return nil, describeJSONError(data, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("format menu tidak valid: ada data setelah daftar menu")
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("menu kosong")
+	}
+
+	menu := make(map[string]MenuEntry, len(entries))
+	skus := make(map[string]string)
+	for i, e := range entries {
+		e.Name = strings.ToLower(strings.Join(strings.Fields(e.Name), " "))
+		e.Category = strings.ToLower(strings.TrimSpace(e.Category))
+		e.Description = strings.TrimSpace(e.Description)
+		e.SKU = strings.TrimSpace(e.SKU)
+		e.TaxClass = strings.ToLower(strings.TrimSpace(e.TaxClass))
+		e.Unit = strings.TrimSpace(e.Unit)
+		for j := range e.Tags {
+			e.Tags[j] = strings.ToLower(strings.Join(strings.Fields(e.Tags[j]), " "))
+		}
+		for j := range e.Aliases {
+			e.Aliases[j] = strings.ToLower(strings.Join(strings.Fields(e.Aliases[j]), " "))
+		}
+		for j := range e.Bundle {
+			e.Bundle[j].Name = strings.ToLower(strings.Join(strings.Fields(e.Bundle[j].Name), " "))
+		}
+		if err := validateMenuEntry(e); err != nil {
+			return nil, fmt.Errorf("item ke-%d: %w", i+1, err)
+		}
+		if _, exists := menu[e.Name]; exists {
+			return nil, fmt.Errorf("item ke-%d: nama '%s' sudah dipakai", i+1, e.Name)
+		}
+		if e.SKU != "" {
+			if other, exists := skus[e.SKU]; exists {
+				return nil, fmt.Errorf("item ke-%d: SKU '%s' sudah dipakai oleh '%s'", i+1, e.SKU, other)
+			}
+			skus[e.SKU] = e.Name
+		}
+		menu[e.Name] = e
+	}
+	if err := validateBundleReferences(menu); err != nil {
+		return nil, err
+	}
+	if err := validateAliases(menu); err != nil {
+		return nil, err
+	}
+	return menu, nil
+}
+
+// validateAliases memastikan alias tidak bentrok dengan nama item atau alias lain
+func validateAliases(menu map[string]MenuEntry) error {
+	owners := make(map[string]string)
+	for _, e := range menu {
+		for _, alias := range e.Aliases {
+			if _, exists := menu[alias]; exists {
+				return fmt.Errorf("alias '%s' pada '%s' sama dengan nama item lain", alias, e.Name)
+			}
+			if owner, exists := owners[alias]; exists {
+				return fmt.Errorf("alias '%s' dipakai oleh '%s' dan '%s'", alias, owner, e.Name)
+			}
+			owners[alias] = e.Name
+		}
+	}
+	return nil
+}
+
+// lookupMenuItem mencari item menu berdasarkan nama atau aliasnya
+func lookupMenuItem(menu MenuRepository, input string) (MenuEntry, bool) {
+	if entry, exists := menu.Get(input); exists {
+		return entry, true
+	}
+	for _, entry := range menu.List() {
+		for _, alias := range entry.Aliases {
+			if alias == input {
+				return entry, true
+			}
+		}
+	}
+	return MenuEntry{}, false
+}
+
+// validateMenuEntry memeriksa field wajib satu item menu
+func validateMenuEntry(e MenuEntry) error {
+	if e.Name == "" {
+		return fmt.Errorf("nama wajib diisi")
+	}
+	if err := validateInput(e.Name); err != nil {
+		return fmt.Errorf("nama '%s': %w", e.Name, err)
+	}
+	if e.Price <= 0 {
+		return fmt.Errorf("harga '%s' harus lebih dari nol", e.Name)
+	}
+	if e.Category == "" {
+		return fmt.Errorf("kategori '%s' wajib diisi", e.Name)
+	}
+	if err := validateModifierGroups(e.Modifiers); err != nil {
+		return fmt.Errorf("modifier '%s': %w", e.Name, err)
+	}
+	if e.Stock != nil && *e.Stock < 0 {
+		return fmt.Errorf("stok '%s' tidak boleh negatif", e.Name)
+	}
+	if e.Unit != "" && (e.Stock != nil || e.isBundle()) {
+		return fmt.Errorf("item bersatuan '%s' tidak bisa memakai stok atau paket", e.Name)
+	}
+	if e.LowStock != nil && (e.Stock == nil || *e.LowStock < 0) {
+		return fmt.Errorf("low_stock '%s' harus tidak negatif dan hanya untuk item dengan stok", e.Name)
+	}
+	if err := validateAvailability(e.Available); err != nil {
+		return fmt.Errorf("jadwal '%s': %w", e.Name, err)
+	}
+	if err := validateSeason(e.ValidFrom, e.ValidUntil); err != nil {
+		return fmt.Errorf("masa berlaku '%s': %w", e.Name, err)
+	}
+	for _, tag := range e.Tags {
+		if tag == "" || tag == "tanpa" || strings.HasPrefix(tag, "tanpa ") {
+			return fmt.Errorf("tag '%s' pada '%s' tidak valid", tag, e.Name)
+		}
+	}
+	for _, alias := range e.Aliases {
+		if err := validateInput(alias); err != nil {
+			return fmt.Errorf("alias '%s' pada '%s': %w", alias, e.Name, err)
+		}
+	}
+	if err := validateBundle(e.Bundle); err != nil {
+		return fmt.Errorf("paket '%s': %w", e.Name, err)
+	}
+	if e.ImageURL != "" {
+		u, err := url.Parse(e.ImageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("image_url '%s' harus berupa URL http atau https", e.Name)
+		}
+	}
+	return nil
+}
+
+// describeJSONError mengubah error JSON menjadi pesan dengan nomor baris
+func describeJSONError(data []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		line, col := lineColumn(data, syntaxErr.Offset)
+		return fmt.Errorf("format menu tidak valid di baris %d kolom %d: %v", line, col, err)
+	case errors.As(err, &typeErr):
+		line, col := lineColumn(data, typeErr.Offset)
+		field := typeErr.Field
+		if i := strings.LastIndex(field, "."); i >= 0 {
+			field = field[i+1:]
+		}
+		return fmt.Errorf("tipe data field '%s' tidak valid di baris %d kolom %d", field, line, col)
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		return fmt.Errorf("field %s tidak dikenal", strings.TrimPrefix(err.Error(), "json: unknown field "))
+	default:
+		return fmt.Errorf("format menu tidak valid: %v", err)
+	}
+}
+
+// lineColumn menghitung baris dan kolom dari offset byte
+func lineColumn(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := int(offset) - bytes.LastIndexByte(before, '\n')
+	return line, col
+}
+
+// saveMenu menyimpan daftar item menu ke file JSON
+func saveMenu(path string, entries []MenuEntry) error {
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("gagal menyimpan menu: %w", err)
+	}
+	return nil
+}
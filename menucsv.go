@@ -0,0 +1,201 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// menuCSVHeader kolom file CSV menu. Modifier, jadwal dan isi paket tidak
+// diekspor; saat impor, nilai itu dipertahankan dari menu yang sudah ada.
+var menuCSVHeader = []string{"name", "price", "category", "description", "sku", "stock"}
+
+// exportMenuCSV menulis menu ke CSV agar bisa diedit di spreadsheet
+func exportMenuCSV(w io.Writer, entries []MenuEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(menuCSVHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		stock := ""
+		if e.Stock != nil {
+			stock = strconv.Itoa(*e.Stock)
+		}
+		record := []string{e.Name, strconv.FormatFloat(e.Price, 'f', -1, 64), e.Category, e.Description, e.SKU, stock}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// importMenuCSV membaca CSV dan menggabungkannya dengan menu yang ada: item dengan
+// nama sama diperbarui, item baru ditambahkan, item yang tidak ada di CSV dibiarkan.
+// Semua kesalahan dikumpulkan per baris; menu hanya dikembalikan jika tidak ada kesalahan.
+func importMenuCSV(r io.Reader, existing []MenuEntry) (menu map[string]MenuEntry, added, updated int, errs []error) {
+	menu = make(map[string]MenuEntry, len(existing))
+	for _, e := range existing {
+		menu[e.Name] = e
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(menuCSVHeader)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, 0, 0, []error{fmt.Errorf("gagal membaca header CSV: %w", err)}
+	}
+	for i, col := range menuCSVHeader {
+		if strings.ToLower(strings.TrimSpace(header[i])) != col {
+			return nil, 0, 0, []error{fmt.Errorf("header CSV harus: %s", strings.Join(menuCSVHeader, ","))}
+		}
+	}
+
+	seen := make(map[string]int)
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				errs = append(errs, fmt.Errorf("baris %d: %v", parseErr.StartLine, parseErr.Err))
+				continue
+			}
+			errs = append(errs, err)
+			break
+		}
+		line, _ := cr.FieldPos(0)
+
+		entry, err := parseMenuCSVRecord(record, menu)
+		if err == nil {
+			if first, dup := seen[entry.Name]; dup {
+				err = fmt.Errorf("nama '%s' sudah dipakai di baris %d", entry.Name, first)
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("baris %d: %w", line, err))
+			continue
+		}
+		seen[entry.Name] = line
+
+		if _, exists := menu[entry.Name]; exists {
+			updated++
+		} else {
+			added++
+		}
+		menu[entry.Name] = entry
+	}
+
+	if len(errs) == 0 {
+		if err := validateBundleReferences(menu); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, 0, 0, errs
+	}
+	return menu, added, updated, nil
+}
+
+// parseMenuCSVRecord mengubah satu baris CSV menjadi item menu. Field yang tidak
+// ada di CSV (modifier, jadwal, paket, gambar) diambil dari item lama jika ada.
+func parseMenuCSVRecord(record []string, menu map[string]MenuEntry) (MenuEntry, error) {
+	name := strings.ToLower(strings.Join(strings.Fields(record[0]), " "))
+	entry := menu[name]
+	entry.Name = name
+	entry.Category = strings.ToLower(strings.TrimSpace(record[2]))
+	entry.Description = strings.TrimSpace(record[3])
+	entry.SKU = strings.TrimSpace(record[4])
+
+	price, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+	if err != nil {
+		return entry, fmt.Errorf("harga '%s' tidak valid", record[1])
+	}
+	entry.Price = price
+
+	entry.Stock = nil
+	if s := strings.TrimSpace(record[5]); s != "" {
+		stock, err := strconv.Atoi(s)
+		if err != nil {
+			return entry, fmt.Errorf("stok '%s' tidak valid", s)
+		}
+		entry.Stock = &stock
+	}
+
+	if err := validateMenuEntry(entry); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
+
+// runMenuCommand menjalankan perintah "menu export <file.csv>" dan
+// "menu import <file.csv>". Flag lain (misalnya -menu atau -role) ditulis setelah nama file.
+// Impor memerlukan izin edit_menu.
+func runMenuCommand(args []string, w io.Writer) error {
+	if len(args) < 2 {
+		return fmt.Errorf("pemakaian: menu export|import <file.csv> [flag]")
+	}
+	action, path := args[0], args[1]
+	cfg, err := parseConfig(args[2:])
+	if err != nil {
+		return err
+	}
+	menuStore, err := openMenuRepository(cfg.MenuPath)
+	if err != nil {
+		return err
+	}
+
+	switch action {
+	case "export":
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("gagal membuat file CSV: %w", err)
+		}
+		if err := exportMenuCSV(f, menuStore.List()); err != nil {
+			f.Close()
+			return fmt.Errorf("gagal menulis CSV: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("gagal menulis CSV: %w", err)
+		}
+		fmt.Fprintf(w, "%d item menu diekspor ke %s\n", len(menuStore.List()), path)
+		return nil
+	case "import":
+		// Impor mengganti menu, sama seperti mode admin hanya untuk role dengan izin edit_menu
+		if roles, err = loadRoles(cfg.RolesPath); err != nil {
+			return err
+		}
+		if err := setRole(cfg.Role); err != nil {
+			return err
+		}
+		if err := requirePermission(PermEditMenu); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("gagal membuka file CSV: %w", err)
+		}
+		defer f.Close()
+		menu, added, updated, errs := importMenuCSV(f, menuStore.List())
+		if len(errs) > 0 {
+			for _, err := range errs {
+				fmt.Fprintf(w, "Error: %v\n", err)
+			}
+			return fmt.Errorf("impor dibatalkan, %d kesalahan ditemukan", len(errs))
+		}
+		menuStore.replace(menu)
+		if err := menuStore.save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Impor selesai: %d item baru, %d item diperbarui, disimpan ke %s\n", added, updated, menuStore.path)
+		return nil
+	default:
+		return fmt.Errorf("perintah menu '%s' tidak dikenal, pakai export atau import", action)
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MenuRepository sumber data menu. Implementasi lain (file, database, remote)
+// bisa dipasang tanpa mengubah loop pemesanan.
+type MenuRepository interface {
+	Get(name string) (MenuEntry, bool)
+	List() []MenuEntry
+	Add(entry MenuEntry) error
+	Remove(name string) error
+	// AdjustStock menambah sisa stok beberapa item sekaligus (delta negatif untuk
+	// mengurangi) dan mengembalikan stok sebelumnya per item yang stoknya dilacak.
+	// Jika ada stok yang menjadi negatif, tidak ada stok yang diubah.
+	AdjustStock(delta map[string]int) (before map[string]int, err error)
+	// Version bertambah setiap kali isi menu berubah, dipakai untuk invalidasi cache
+	Version() uint64
+	// Snapshot salinan menu saat ini yang tidak ikut berubah saat menu dimuat ulang
+	Snapshot() MenuRepository
+}
+
+// InMemoryMenuRepository menyimpan menu di memori dan aman dipakai bersamaan
+type InMemoryMenuRepository struct {
+	mu      sync.RWMutex
+	items   map[string]MenuEntry
+	version uint64
+}
+
+// NewInMemoryMenuRepository membuat repository dari daftar item menu
+func NewInMemoryMenuRepository(entries map[string]MenuEntry) *InMemoryMenuRepository {
+	items := make(map[string]MenuEntry, len(entries))
+	for name, entry := range entries {
+		items[name] = entry
+	}
+	return &InMemoryMenuRepository{items: items, version: 1}
+}
+
+// Get mengambil item menu berdasarkan nama
+func (r *InMemoryMenuRepository) Get(name string) (MenuEntry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entry, exists := r.items[name]
+	return entry, exists
+}
+
+// List mengembalikan semua item menu, diurutkan berdasarkan nama
+func (r *InMemoryMenuRepository) List() []MenuEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	entries := make([]MenuEntry, 0, len(r.items))
+	for _, entry := range r.items {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// Add menambahkan item baru atau mengganti item dengan nama yang sama
+func (r *InMemoryMenuRepository) Add(entry MenuEntry) error {
+	if err := validateMenuEntry(entry); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items[entry.Name] = entry
+	r.version++
+	return nil
+}
+
+// Remove menghapus item menu berdasarkan nama
+func (r *InMemoryMenuRepository) Remove(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.items[name]; !exists {
+		return fmt.Errorf("Menu '%s' tidak tersedia", name)
+	}
+	delete(r.items, name)
+	r.version++
+	return nil
+}
+
+// Snapshot membuat salinan menu dengan versi yang sama
+func (r *InMemoryMenuRepository) Snapshot() MenuRepository {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	snapshot := NewInMemoryMenuRepository(r.items)
+	snapshot.version = r.version
+	return snapshot
+}
+
+// AdjustStock mengubah stok beberapa item dalam satu kunci, setelah semua item diperiksa
+func (r *InMemoryMenuRepository) AdjustStock(delta map[string]int) (map[string]int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	before := make(map[string]int)
+	for name, d := range delta {
+		entry, exists := r.items[name]
+		if !exists || entry.Stock == nil {
+			continue
+		}
+		if *entry.Stock+d < 0 {
+			return nil, fmt.Errorf("stok '%s' tersisa %d", name, *entry.Stock)
+		}
+		before[name] = *entry.Stock
+	}
+	for name, stock := range before {
+		entry := r.items[name]
+		after := stock + delta[name]
+		entry.Stock = &after
+		r.items[name] = entry
+	}
+	if len(before) > 0 {
+		r.version++
+	}
+	return before, nil
+}
+
+// replace mengganti seluruh isi menu sekaligus
+func (r *InMemoryMenuRepository) replace(entries map[string]MenuEntry) {
+	items := make(map[string]MenuEntry, len(entries))
+	for name, entry := range entries {
+		items[name] = entry
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = items
+	r.version++
+}
+
+// update mengganti seluruh isi menu dengan hasil fn dari isi menu saat ini dalam
+// satu kunci, sehingga perubahan lain tidak terselip di antaranya. fn tidak boleh
+// mengubah map yang diterimanya.
+func (r *InMemoryMenuRepository) update(fn func(current map[string]MenuEntry) map[string]MenuEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.items = fn(r.items)
+	r.version++
+}
+
+// Version mengembalikan versi isi menu saat ini
+func (r *InMemoryMenuRepository) Version() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version
+}
+
+// menuReloadInterval jeda pemeriksaan perubahan file menu
+const menuReloadInterval = 2 * time.Second
+
+// FileMenuRepository menyimpan menu di memori dan menulisnya ke file JSON setiap ada perubahan
+type FileMenuRepository struct {
+	*InMemoryMenuRepository
+	path string
+
+	// statMu melindungi info file terakhir yang diketahui, untuk mendeteksi perubahan dari luar
+	statMu  sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+// openMenuRepository membuka menu sesuai flag -menu. Menu bawaan yang diubah
+// disimpan ke menu.json agar dipakai lagi saat program berikutnya dijalankan.
+func openMenuRepository(flagPath string) (*FileMenuRepository, error) {
+	path := resolveMenuPath(flagPath)
+	entries, err := loadMenu(path)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		path = defaultMenuPath
+	}
+	repo := &FileMenuRepository{InMemoryMenuRepository: NewInMemoryMenuRepository(entries), path: path}
+	repo.fileChanged()
+	return repo, nil
+}
+
+// fileChanged mencatat info file menu dan mengembalikan true jika berbeda dari catatan sebelumnya
+func (r *FileMenuRepository) fileChanged() bool {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false
+	}
+	r.statMu.Lock()
+	defer r.statMu.Unlock()
+	if info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return false
+	}
+	r.modTime, r.size = info.ModTime(), info.Size()
+	return true
+}
+
+// save menulis menu ke file tanpa memicu muat ulang oleh Watch
+func (r *FileMenuRepository) save() error {
+	if err := saveMenu(r.path, r.List()); err != nil {
+		return err
+	}
+	r.fileChanged()
+	return nil
+}
+
+// Watch memeriksa file menu secara berkala dan memuat ulang jika diubah dari luar.
+// Menu yang tidak valid diabaikan dan menu lama tetap dipakai. onReload dipanggil
+// setiap kali file berubah dengan hasil pemuatan ulang. Panggil fungsi yang
+// dikembalikan untuk berhenti memeriksa.
+func (r *FileMenuRepository) Watch(interval time.Duration, onReload func(error)) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if !r.fileChanged() {
+					continue
+				}
+				entries, err := loadMenu(r.path)
+				if err == nil {
+					r.replace(entries)
+				}
+				onReload(err)
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// Add menambahkan atau mengganti item lalu menyimpan menu ke file
+func (r *FileMenuRepository) Add(entry MenuEntry) error {
+	if err := r.InMemoryMenuRepository.Add(entry); err != nil {
+		return err
+	}
+	return r.save()
+}
+
+// AdjustStock mengubah stok lalu menyimpan menu ke file. Jika gagal disimpan,
+// stok di memori dikembalikan agar tetap sama dengan isi file.
+func (r *FileMenuRepository) AdjustStock(delta map[string]int) (map[string]int, error) {
+	before, err := r.InMemoryMenuRepository.AdjustStock(delta)
+	if err != nil || len(before) == 0 {
+		return before, err
+	}
+	if err := r.save(); err != nil {
+		undo := make(map[string]int, len(before))
+		for name := range before {
+			undo[name] = -delta[name]
+		}
+		r.InMemoryMenuRepository.AdjustStock(undo)
+		return nil, err
+	}
+	return before, nil
+}
+
+// Remove menghapus item lalu menyimpan menu ke file
+func (r *FileMenuRepository) Remove(name string) error {
+	if err := r.InMemoryMenuRepository.Remove(name); err != nil {
+		return err
+	}
+	return r.save()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sameLine mengecek apakah dua baris pesanan bisa digabung menjadi satu baris:
+// item, harga, kursi, modifier dan catatannya sama persis
+func sameLine(a, b *MenuItem) bool {
+	return a.Name == b.Name &&
+		a.Price == b.Price &&
+		a.Unit == b.Unit &&
+		a.Seat == b.Seat &&
+		a.Note == b.Note &&
+		a.TaxClass == b.TaxClass &&
+		slices.Equal(a.Modifiers, b.Modifiers) &&
+		slices.Equal(a.Components, b.Components)
+}
+
+// Merge memindahkan semua item dari pesanan other ke pesanan ini, misalnya saat
+// dua meja memutuskan membayar bersama. Baris yang sama persis dijumlahkan,
+// baris lain ditambahkan apa adanya sehingga modifier dan catatan tetap utuh.
+// Field tambahan ikut dipindahkan; nilai yang berbeda untuk field yang sama ditolak.
+// Pesanan other dikosongkan setelah digabung. Pesanan other yang masih memakai
+// diskon ditolak agar potongannya tidak hilang diam-diam.
+func (o *Order) Merge(other *Order) error {
+	if other == o {
+		return fmt.Errorf("pesanan tidak bisa digabung dengan dirinya sendiri")
+	}
+	if err := o.requireDraft(); err != nil {
+		return err
+	}
+	if err := other.requireDraft(); err != nil {
+		return err
+	}
+	if o.Training != other.Training {
+		return fmt.Errorf("pesanan latihan tidak bisa digabung dengan pesanan asli")
+	}
+	// Potongan meja lain dihitung dari subtotalnya sendiri dan tidak bisa dipindahkan apa adanya
+	if other.Discount != nil {
+		return fmt.Errorf("pesanan yang digabung masih memakai %s; hapus dengan 'diskon hapus' di meja tersebut lalu beri diskon lagi setelah digabung", strings.ToLower(other.Discount.Label()))
+	}
+
+	var fields []CustomFieldValue
+	for _, field := range other.CustomFields {
+		if value, exists := o.customField(field.Name); exists {
+			if value != field.Value {
+				return fmt.Errorf("field %s berbeda (%s dan %s); samakan dulu sebelum digabung", field.Name, value, field.Value)
+			}
+			continue
+		}
+		fields = append(fields, field)
+	}
+
+	for _, item := range other.Items {
+		merged := false
+		for _, existing := range o.Items {
+			if sameLine(existing, item) {
+				existing.Quantity += item.Quantity
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			o.Items = append(o.Items, item)
+		}
+	}
+	o.CustomFields = append(o.CustomFields, fields...)
+	// Baris dari meja lain belum pernah diperiksa, peringatan harus dikonfirmasi ulang
+	o.Warnings, o.WarningsAcknowledged = nil, false
+
+	other.Items, other.CustomFields = nil, nil
+	other.calculateTotal()
+	o.calculateTotal()
+	return nil
+}
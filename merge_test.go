@@ -0,0 +1,132 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeConsolidatesLines(t *testing.T) {
+	spicy := []SelectedModifier{{Group: "level", Option: "pedas"}}
+
+	order := NewOrder()
+	order.AddItem("Nasi Goreng", 25000, 1)
+	order.AddItemForSeat("Ayam Bakar", 30000, 1, 2)
+	order.AddItem("Es Teh", 5000, 1)
+	order.Items[2].Note = "tanpa gula"
+
+	other := NewOrder()
+	other.AddItem("Nasi Goreng", 25000, 2)          // sama persis, dijumlahkan
+	other.AddItemForSeat("Ayam Bakar", 30000, 1, 3) // kursi berbeda
+	other.AddItem("Es Teh", 5000, 2)                // catatan berbeda
+	other.AddItem("Nasi Goreng", 25000, 1)
+	other.Items[3].Modifiers = spicy  // modifier berbeda
+	other.AddItem("Kerupuk", 5000, 1) // item baru
+
+	if err := order.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		name     string
+		quantity float64
+		seat     int
+		note     string
+		mods     int
+	}{
+		{"Nasi Goreng", 3, 0, "", 0},
+		{"Ayam Bakar", 1, 2, "", 0},
+		{"Es Teh", 1, 0, "tanpa gula", 0},
+		{"Ayam Bakar", 1, 3, "", 0},
+		{"Es Teh", 2, 0, "", 0},
+		{"Nasi Goreng", 1, 0, "", 1},
+		{"Kerupuk", 1, 0, "", 0},
+	}
+	if len(order.Items) != len(want) {
+		t.Fatalf("jumlah baris = %d, ingin %d", len(order.Items), len(want))
+	}
+	for i, w := range want {
+		item := order.Items[i]
+		if item.Name != w.name || item.Quantity != w.quantity || item.Seat != w.seat || item.Note != w.note || len(item.Modifiers) != w.mods {
+			t.Errorf("baris %d = %s x%v kursi %d catatan %q modifier %d, ingin %+v",
+				i+1, item.Name, item.Quantity, item.Seat, item.Note, len(item.Modifiers), w)
+		}
+	}
+
+	if len(other.Items) != 0 || other.Total != 0 {
+		t.Errorf("pesanan yang digabung masih berisi %d item, total %v", len(other.Items), other.Total)
+	}
+	if want := 25000*3 + 30000 + 5000 + 30000 + 5000*2 + 25000 + 5000; order.Total != float64(want) {
+		t.Errorf("total = %v, ingin %v", order.Total, want)
+	}
+}
+
+func TestMergeRejects(t *testing.T) {
+	confirmed := newTestOrder()
+	confirmed.Confirm()
+	training := newTestOrder()
+	training.Training = true
+	self := newTestOrder()
+	discounted := newTestOrder()
+	if err := discounted.ApplyDiscount(DiscountPercent, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		into    *Order
+		other   *Order
+		wantErr string
+	}{
+		{"dengan dirinya sendiri", self, self, "dirinya sendiri"},
+		{"tujuan sudah dikonfirmasi", confirmed, newTestOrder(), "sudah dikonfirmasi"},
+		{"sumber sudah dikonfirmasi", newTestOrder(), confirmed, "sudah dikonfirmasi"},
+		{"latihan dengan asli", newTestOrder(), training, "latihan"},
+		{"sumber memakai diskon", newTestOrder(), discounted, "diskon hapus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(tt.other.Items)
+			err := tt.into.Merge(tt.other)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, ingin mengandung %q", err, tt.wantErr)
+			}
+			if len(tt.other.Items) != before {
+				t.Error("item pesanan sumber berubah walaupun penggabungan ditolak")
+			}
+		})
+	}
+}
+
+func TestMergeCustomFieldsAndWarnings(t *testing.T) {
+	order := newTestOrder()
+	order.CustomFields = []CustomFieldValue{{Name: "nama pelanggan", Value: "Budi"}}
+	order.Warnings = []ValidationWarning{{Code: "harga_nol", Message: "harga 'air putih' nol"}}
+	order.AcknowledgeWarnings()
+
+	other := newTestOrder()
+	other.CustomFields = []CustomFieldValue{
+		{Name: "nama pelanggan", Value: "Budi"},
+		{Name: "no. HP", Value: "08123456789"},
+	}
+	if err := order.Merge(other); err != nil {
+		t.Fatal(err)
+	}
+	if len(order.CustomFields) != 2 || order.CustomFields[1].Value != "08123456789" {
+		t.Errorf("field tambahan = %+v", order.CustomFields)
+	}
+	if order.Warnings != nil || order.WarningsAcknowledged {
+		t.Error("peringatan yang sudah dikonfirmasi tetap berlaku untuk baris dari meja lain")
+	}
+	if other.CustomFields != nil {
+		t.Errorf("field tambahan pesanan sumber masih ada: %+v", other.CustomFields)
+	}
+
+	conflict := newTestOrder()
+	conflict.CustomFields = []CustomFieldValue{{Name: "nama pelanggan", Value: "Sari"}}
+	if err := order.Merge(conflict); err == nil || !strings.Contains(err.Error(), "nama pelanggan") {
+		t.Fatalf("error = %v, ingin field berbeda ditolak", err)
+	}
+	if len(conflict.Items) != 3 || len(order.CustomFields) != 2 {
+		t.Error("pesanan berubah walaupun penggabungan ditolak")
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ModifierOption satu pilihan pada grup modifier, misalnya "telur" +Rp5000
+type ModifierOption struct {
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+// ModifierGroup grup pilihan pada item menu, misalnya "level pedas" atau "extra topping"
+type ModifierGroup struct {
+	Name     string           `json:"name"`
+	Required bool             `json:"required"` // harus memilih tepat satu jika Multiple false
+	Multiple bool             `json:"multiple"` // boleh memilih lebih dari satu
+	Options  []ModifierOption `json:"options"`
+}
+
+// SelectedModifier pilihan modifier yang dipilih pada baris pesanan
+type SelectedModifier struct {
+	Group  string
+	Option string
+	Price  float64
+}
+
+// UnitPrice harga satuan item termasuk modifier
+func (m *MenuItem) UnitPrice() float64 {
+	price := m.Price
+	for _, mod := range m.Modifiers {
+		price += mod.Price
+	}
+	return price
+}
+
+// LineTotal total harga baris pesanan
+func (m *MenuItem) LineTotal() float64 {
+	return m.UnitPrice() * m.Quantity
+}
+
+// validateModifierGroups memeriksa definisi modifier pada satu item menu
+func validateModifierGroups(groups []ModifierGroup) error {
+	seen := make(map[string]bool)
+	for _, g := range groups {
+		if strings.TrimSpace(g.Name) == "" {
+			return fmt.Errorf("nama grup modifier wajib diisi")
+		}
+		if seen[g.Name] {
+			return fmt.Errorf("grup modifier '%s' didefinisikan lebih dari sekali", g.Name)
+		}
+		seen[g.Name] = true
+		if len(g.Options) == 0 {
+			return fmt.Errorf("grup modifier '%s' tidak memiliki pilihan", g.Name)
+		}
+		for _, o := range g.Options {
+			if strings.TrimSpace(o.Name) == "" {
+				return fmt.Errorf("pilihan pada grup '%s' wajib memiliki nama", g.Name)
+			}
+			if o.Price < 0 {
+				return fmt.Errorf("harga pilihan '%s' tidak boleh negatif", o.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// hasRequiredModifiers mengecek apakah item menu memiliki grup modifier wajib
+func (e MenuEntry) hasRequiredModifiers() bool {
+	for _, g := range e.Modifiers {
+		if g.Required {
+			return true
+		}
+	}
+	return false
+}
+
+// promptModifiers menanyakan pilihan modifier untuk item menu sampai valid
+func promptModifiers(reader *bufio.Reader, entry MenuEntry) ([]SelectedModifier, error) {
+	var selected []SelectedModifier
+	for _, group := range entry.Modifiers {
+		fmt.Printf("\nPilihan %s:\n", group.Name)
+		for i, opt := range group.Options {
+			fmt.Printf("  %d. %s (+%s)\n", i+1, opt.Name, currentLocale.FormatMoney(opt.Price))
+		}
+
+		for {
+			hint := "nomor"
+			if group.Multiple {
+				hint = "nomor, pisahkan dengan koma"
+			}
+			if !group.Required {
+				hint += ", kosongkan untuk lewati"
+			}
+			fmt.Printf("Pilih %s [%s]: ", group.Name, hint)
+			answer, err := readLine(reader)
+			if err != nil {
+				return nil, err
+			}
+			choices, err := parseModifierChoice(group, answer)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			selected = append(selected, choices...)
+			break
+		}
+	}
+	return selected, nil
+}
+
+// parseModifierChoice mem-parsing nomor pilihan modifier yang dimasukkan kasir
+func parseModifierChoice(group ModifierGroup, answer string) ([]SelectedModifier, error) {
+	if answer == "" {
+		if group.Required {
+			return nil, fmt.Errorf("%s wajib dipilih", group.Name)
+		}
+		return nil, nil
+	}
+
+	parts := strings.Split(answer, ",")
+	if len(parts) > 1 && !group.Multiple {
+		return nil, fmt.Errorf("%s hanya boleh dipilih satu", group.Name)
+	}
+
+	var choices []SelectedModifier
+	picked := make(map[int]bool)
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 || n > len(group.Options) {
+			return nil, fmt.Errorf("pilihan '%s' tidak valid", strings.TrimSpace(part))
+		}
+		if picked[n] {
+			continue
+		}
+		picked[n] = true
+		opt := group.Options[n-1]
+		choices = append(choices, SelectedModifier{Group: group.Name, Option: opt.Name, Price: opt.Price})
+	}
+	return choices, nil
+}
+
+// describeModifiers menggabungkan modifier menjadi teks, misalnya "level pedas: 3, extra: telur"
+func describeModifiers(mods []SelectedModifier) string {
+	parts := make([]string, len(mods))
+	for i, mod := range mods {
+		parts[i] = mod.Group + ": " + mod.Option
+	}
+	return strings.Join(parts, ", ")
+}
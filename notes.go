@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxNoteLength panjang maksimal catatan per item agar tetap muat di struk dan tiket dapur
+const maxNoteLength = 80
+
+// validateNote memeriksa catatan item, misalnya "tanpa bawang" atau "pedas level 3"
+func validateNote(note string) error {
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return fmt.Errorf("catatan maksimal %d karakter", maxNoteLength)
+	}
+	if strings.IndexFunc(note, unicode.IsControl) >= 0 {
+		return fmt.Errorf("catatan tidak boleh berisi karakter kontrol")
+	}
+	return nil
+}
+
+// promptNote menanyakan catatan opsional untuk item yang baru ditambahkan
+func promptNote(reader *bufio.Reader) (string, error) {
+	for {
+		fmt.Print("Catatan [kosongkan jika tidak ada]: ")
+		note, err := readLine(reader)
+		if err != nil {
+			return "", err
+		}
+		if err := validateNote(note); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			continue
+		}
+		return note, nil
+	}
+}
+
+// SetNote mengganti catatan item ke-index (dihitung dari 0); catatan kosong menghapusnya
+func (o *Order) SetNote(index int, note string) error {
+	if err := o.requireDraft(); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(o.Items) {
+		return fmt.Errorf("item nomor %d tidak ada di pesanan", index+1)
+	}
+	note = strings.TrimSpace(note)
+	if err := validateNote(note); err != nil {
+		return err
+	}
+	o.Items[index].Note = note
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// onboardingCurrencies pilihan mata uang pada panduan penyiapan, sesuai locale bawaan
+var onboardingCurrencies = []struct {
+	Label  string
+	Locale string
+}{
+	{"Rupiah (Rp)", "id-ID"},
+	{"Dolar AS ($)", "en-US"},
+	{"Ringgit (RM)", "ms-MY"},
+}
+
+// needsOnboarding mengecek apakah ini peluncuran pertama: belum ada file
+// konfigurasi maupun menu, dan program dijalankan dari terminal
+// (bukan dari input yang di-pipe)
+func needsOnboarding() bool {
+	for _, path := range []string{defaultConfigPath, defaultMenuPath} {
+		if _, err := os.Stat(path); err == nil {
+			return false
+		}
+	}
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// runOnboarding memandu pemilik toko menyiapkan toko pertama kali lalu menulis
+// file konfigurasi dan menu, sehingga tidak perlu mengedit file secara manual
+func runOnboarding(reader *bufio.Reader, w io.Writer) error {
+	prompt := func(label string) (string, error) {
+		fmt.Fprint(w, label)
+		line, err := readLine(reader)
+		return strings.TrimSpace(line), err
+	}
+
+	fmt.Fprintln(w, "Selamat datang! Konfigurasi belum ada, mari siapkan toko Anda.")
+
+	var storeID string
+	for storeID == "" {
+		name, err := prompt("Nama toko: ")
+		if err != nil {
+			return err
+		}
+		storeID = strings.Join(strings.Fields(strings.ToLower(name)), "-")
+	}
+
+	fmt.Fprintln(w, "\nMata uang:")
+	for i, c := range onboardingCurrencies {
+		fmt.Fprintf(w, "%d. %s\n", i+1, c.Label)
+	}
+	var locale string
+	for locale == "" {
+		answer, err := prompt("Pilih nomor: ")
+		if err != nil {
+			return err
+		}
+		var choice int
+		if _, err := fmt.Sscan(answer, &choice); err == nil && choice >= 1 && choice <= len(onboardingCurrencies) {
+			locale = onboardingCurrencies[choice-1].Locale
+			continue
+		}
+		fmt.Fprintln(w, "Error: pilih salah satu nomor di atas")
+	}
+
+	fmt.Fprintln(w, "\nMenu:")
+	fmt.Fprintln(w, "1. Pakai menu contoh (bisa diubah nanti lewat perintah 'admin')")
+	fmt.Fprintln(w, "2. Impor dari file menu JSON")
+	var menu map[string]MenuEntry
+	for menu == nil {
+		answer, err := prompt("Pilih nomor: ")
+		if err != nil {
+			return err
+		}
+		switch answer {
+		case "1":
+			menu, err = loadMenu("")
+		case "2":
+			var path string
+			if path, err = prompt("Path file menu: "); err != nil {
+				return err
+			}
+			menu, err = loadMenu(path)
+		default:
+			err = fmt.Errorf("pilih 1 atau 2")
+		}
+		if err != nil {
+			fmt.Fprintf(w, "Error: %v\n", err)
+		}
+	}
+
+	entries := NewInMemoryMenuRepository(menu).List()
+	if err := saveMenu(defaultMenuPath, entries); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(map[string]string{
+		"store":  storeID,
+		"locale": locale,
+		"menu":   defaultMenuPath,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(defaultConfigPath, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("gagal menyimpan konfigurasi: %w", err)
+	}
+
+	fmt.Fprintf(w, "\nKonfigurasi disimpan ke %s dan menu ke %s. Toko siap dipakai!\n", defaultConfigPath, defaultMenuPath)
+	return nil
+}
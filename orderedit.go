@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// runOrderEditCommand menjalankan perintah "hapus <nomor>", "ubah <nomor> <jumlah>"
+// atau "catatan <nomor> [teks]".
+// Nomor item sesuai urutan pada ringkasan pesanan, dimulai dari 1.
+// Stok diperiksa pada menu live terhadap semua pesanan yang masih terbuka.
+func runOrderEditCommand(input string, order *Order, menu MenuRepository, open []*Order) error {
+	fields := strings.Fields(input)
+	if len(fields) < 2 {
+		return fmt.Errorf("nomor item wajib diisi")
+	}
+	number, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return fmt.Errorf("nomor item tidak valid: %s", fields[1])
+	}
+	index := number - 1
+
+	switch fields[0] {
+	case "hapus":
+		if len(fields) != 2 {
+			return fmt.Errorf("format: hapus <nomor>")
+		}
+		return order.RemoveItem(index)
+	case "ubah":
+		if len(fields) != 3 {
+			return fmt.Errorf("format: ubah <nomor> <jumlah>")
+		}
+		quantity, err := parseQuantity(fields[2])
+		if err != nil {
+			return err
+		}
+		if index < 0 || index >= len(order.Items) {
+			return order.UpdateQuantity(index, quantity)
+		}
+		// Stok hanya diperiksa untuk tambahan jumlahnya saja
+		item := order.Items[index]
+		if entry, exists := menu.Get(item.Name); exists && quantity > item.Quantity {
+			if err := checkStock(open, menu, entry, quantity-item.Quantity); err != nil {
+				return err
+			}
+		}
+		return order.UpdateQuantity(index, quantity)
+	case "catatan":
+		return order.SetNote(index, strings.Join(fields[2:], " "))
+	}
+	return fmt.Errorf("perintah '%s' tidak dikenal", fields[0])
+}
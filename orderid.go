@@ -0,0 +1,33 @@
+package main
+
+import (
+	"crypto/rand"
+	"math/big"
+	"time"
+)
+
+// orderIDAlphabet alfabet base32 Crockford yang dipakai ULID
+const orderIDAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newOrderID membuat ID pesanan berformat ULID: 48 bit waktu dalam milidetik
+// diikuti 80 bit acak. ID urut sesuai waktu pembuatan dan unik tanpa perlu
+// menyimpan penghitung di file, berbeda dengan kode pesanan yang hanya unik per hari.
+func newOrderID(t time.Time) string {
+	var b [16]byte
+	ms := uint64(t.UnixMilli())
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ms >> (40 - 8*i))
+	}
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic(err)
+	}
+
+	// 26 karakter x 5 bit = 130 bit, dua bit teratas selalu nol
+	n := new(big.Int).SetBytes(b[:])
+	out := make([]byte, 26)
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = orderIDAlphabet[n.Uint64()&31]
+		n.Rsh(n, 5)
+	}
+	return string(out)
+}
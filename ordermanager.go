@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// tablePattern nomor meja atau tiket: huruf, angka dan tanda hubung
+var tablePattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Ticket satu pesanan terbuka pada meja atau nomor tiket tertentu
+type Ticket struct {
+	Table string
+	Order *Order
+	// Menu salinan menu yang dipakai pesanan ini, lihat MenuRepository.Snapshot
+	Menu MenuRepository
+}
+
+// OrderManager menyimpan pesanan yang sedang terbuka per meja/tiket, sehingga
+// kasir bisa berpindah antar pesanan dalam satu sesi. Aman dipakai bersamaan.
+type OrderManager struct {
+	mu      sync.Mutex
+	tickets map[string]*Ticket
+	open    func() *Ticket
+}
+
+// NewOrderManager membuat manager baru; open dipanggil untuk membuat pesanan meja baru
+func NewOrderManager(open func() *Ticket) *OrderManager {
+	return &OrderManager{tickets: make(map[string]*Ticket), open: open}
+}
+
+// Open mengembalikan pesanan terbuka pada meja, atau membuka pesanan baru jika belum ada.
+// created bernilai true jika pesanan baru dibuat.
+func (m *OrderManager) Open(table string) (ticket *Ticket, created bool, err error) {
+	if !tablePattern.MatchString(table) {
+		return nil, false, fmt.Errorf("nomor meja tidak valid: '%s'", table)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if ticket, exists := m.tickets[table]; exists {
+		return ticket, false, nil
+	}
+	ticket = m.open()
+	ticket.Table = table
+	m.tickets[table] = ticket
+	return ticket, true, nil
+}
+
+// Get mengembalikan pesanan terbuka pada meja
+func (m *OrderManager) Get(table string) (*Ticket, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ticket, exists := m.tickets[table]
+	return ticket, exists
+}
+
+// Close menutup pesanan meja setelah dibayar atau dibatalkan
+func (m *OrderManager) Close(table string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.tickets[table]; !exists {
+		return fmt.Errorf("tidak ada pesanan terbuka di meja %s", table)
+	}
+	delete(m.tickets, table)
+	return nil
+}
+
+// List mengembalikan semua pesanan terbuka, diurutkan per nomor meja
+func (m *OrderManager) List() []*Ticket {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tickets := make([]*Ticket, 0, len(m.tickets))
+	for _, ticket := range m.tickets {
+		tickets = append(tickets, ticket)
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		return tableLess(tickets[i].Table, tickets[j].Table)
+	})
+	return tickets
+}
+
+// Orders mengembalikan pesanan dari semua tiket yang masih terbuka
+func (m *OrderManager) Orders() []*Order {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	orders := make([]*Order, 0, len(m.tickets))
+	for _, ticket := range m.tickets {
+		orders = append(orders, ticket.Order)
+	}
+	return orders
+}
+
+// tableLess mengurutkan nomor meja angka secara numerik (2 sebelum 10), lalu nama lain secara alfabetis
+func tableLess(a, b string) bool {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return x < y
+	case errA == nil || errB == nil:
+		return errA == nil
+	}
+	return a < b
+}
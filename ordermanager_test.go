@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// newTestOrderManager manager yang membuka pesanan kosong dengan menu kosong
+func newTestOrderManager() *OrderManager {
+	return NewOrderManager(func() *Ticket {
+		return &Ticket{Order: NewOrder(), Menu: NewInMemoryMenuRepository(nil)}
+	})
+}
+
+func TestOrderManagerOpen(t *testing.T) {
+	m := newTestOrderManager()
+	first, created, err := m.Open("5")
+	if err != nil || !created {
+		t.Fatalf("Open pertama: created %v, error %v", created, err)
+	}
+	again, created, err := m.Open("5")
+	if err != nil || created || again != first {
+		t.Errorf("Open kedua: created %v, error %v, tiket sama %v", created, err, again == first)
+	}
+	if first.Table != "5" {
+		t.Errorf("meja = %s, ingin 5", first.Table)
+	}
+
+	for _, table := range []string{"", "Meja 1", "a/b"} {
+		if _, _, err := m.Open(table); err == nil {
+			t.Errorf("Open(%q) tidak ditolak", table)
+		}
+	}
+}
+
+func TestOrderManagerClose(t *testing.T) {
+	m := newTestOrderManager()
+	old, _, _ := m.Open("1")
+	if err := m.Close("1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Close("1"); err == nil {
+		t.Error("Close meja yang sudah ditutup tidak ditolak")
+	}
+	if _, exists := m.Get("1"); exists {
+		t.Error("meja yang ditutup masih terbuka")
+	}
+	// Membuka ulang meja yang sama memberi pesanan baru
+	reopened, created, _ := m.Open("1")
+	if !created || reopened.Order == old.Order {
+		t.Error("meja yang dibuka ulang memakai pesanan lama")
+	}
+}
+
+func TestOrderManagerListSorted(t *testing.T) {
+	m := newTestOrderManager()
+	for _, table := range []string{"10", "teras", "2", "1", "bar"} {
+		m.Open(table)
+	}
+	var got []string
+	for _, ticket := range m.List() {
+		got = append(got, ticket.Table)
+	}
+	if fmt.Sprint(got) != "[1 2 10 bar teras]" {
+		t.Errorf("urutan meja = %v", got)
+	}
+	if len(m.Orders()) != 5 {
+		t.Errorf("jumlah pesanan terbuka = %d, ingin 5", len(m.Orders()))
+	}
+}
+
+func TestOrderManagerConcurrent(t *testing.T) {
+	m := newTestOrderManager()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				table := fmt.Sprintf("%d", (w*50+i)%20)
+				ticket, _, err := m.Open(table)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if got, exists := m.Get(table); exists && got.Table != table {
+					t.Errorf("Get(%s) mengembalikan meja %s", table, got.Table)
+				}
+				m.List()
+				m.Orders()
+				if i%3 == 0 {
+					// Meja bisa sudah ditutup goroutine lain
+					m.Close(ticket.Table)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	// Setiap meja yang masih terbuka hanya punya satu tiket
+	seen := make(map[string]bool)
+	for _, ticket := range m.List() {
+		if seen[ticket.Table] {
+			t.Errorf("meja %s terbuka lebih dari sekali", ticket.Table)
+		}
+		seen[ticket.Table] = true
+	}
+}
+
+func TestOrderManagerConcurrentOpenSameTable(t *testing.T) {
+	m := newTestOrderManager()
+	tickets := make([]*Ticket, 16)
+	var wg sync.WaitGroup
+	for i := range tickets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tickets[i], _, _ = m.Open("7")
+		}(i)
+	}
+	wg.Wait()
+	for i, ticket := range tickets {
+		if ticket != tickets[0] {
+			t.Fatalf("Open bersamaan ke-%d membuat tiket berbeda untuk meja yang sama", i)
+		}
+	}
+}
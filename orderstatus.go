@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTransition dikembalikan saat status pesanan diubah ke status yang tidak diizinkan
+var ErrInvalidTransition = errors.New("perubahan status pesanan tidak diizinkan")
+
+// OrderStatus tahap siklus hidup pesanan
+type OrderStatus string
+
+const (
+	StatusDraft      OrderStatus = "draft"
+	StatusConfirmed  OrderStatus = "dikonfirmasi"
+	StatusPaid       OrderStatus = "dibayar"
+	StatusProcessing OrderStatus = "diproses"
+	StatusCompleted  OrderStatus = "selesai"
+	StatusCancelled  OrderStatus = "dibatalkan"
+)
+
+// orderTransitions status tujuan yang boleh dicapai dari setiap status.
+// Pesanan yang sedang diproses tidak bisa dibatalkan lagi.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	StatusDraft:      {StatusConfirmed, StatusCancelled},
+	StatusConfirmed:  {StatusPaid, StatusCancelled},
+	StatusPaid:       {StatusProcessing, StatusCancelled},
+	StatusProcessing: {StatusCompleted},
+}
+
+// canTransition mengecek apakah pesanan boleh berpindah ke status to
+func (o *Order) canTransition(to OrderStatus) error {
+	for _, next := range orderTransitions[o.Status] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s ke %s", ErrInvalidTransition, o.Status, to)
+}
+
+// transition memindahkan pesanan ke status to jika diizinkan
+func (o *Order) transition(to OrderStatus) error {
+	if err := o.canTransition(to); err != nil {
+		return err
+	}
+	o.Status = to
+	return nil
+}
+
+// Confirm menandai pesanan sudah dikonfirmasi; item tidak bisa diubah lagi
+func (o *Order) Confirm() error {
+	return o.transition(StatusConfirmed)
+}
+
+// MarkPaid mencatat pembayaran pesanan yang sudah dikonfirmasi
+func (o *Order) MarkPaid() error {
+	return o.transition(StatusPaid)
+}
+
+// StartProcessing menandai pesanan mulai diproses processor
+func (o *Order) StartProcessing() error {
+	return o.transition(StatusProcessing)
+}
+
+// Complete menandai pesanan selesai diproses
+func (o *Order) Complete() error {
+	return o.transition(StatusCompleted)
+}
+
+// Cancel membatalkan pesanan yang belum diproses dan mencatat alasannya
+func (o *Order) Cancel(reason CancelReason) error {
+	if err := o.transition(StatusCancelled); err != nil {
+		return err
+	}
+	o.CancelReason = reason
+	o.CancelledAt = clock.Now()
+	return nil
+}
+
+// requireDraft memastikan item pesanan masih boleh diubah
+func (o *Order) requireDraft() error {
+	if o.Status != StatusDraft {
+		return fmt.Errorf("pesanan sudah %s, item tidak bisa diubah", o.Status)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOrderTransitions(t *testing.T) {
+	statuses := []OrderStatus{StatusDraft, StatusConfirmed, StatusPaid, StatusProcessing, StatusCompleted, StatusCancelled}
+	allowed := map[OrderStatus][]OrderStatus{
+		StatusDraft:      {StatusConfirmed, StatusCancelled},
+		StatusConfirmed:  {StatusPaid, StatusCancelled},
+		StatusPaid:       {StatusProcessing, StatusCancelled},
+		StatusProcessing: {StatusCompleted},
+	}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := false
+			for _, next := range allowed[from] {
+				want = want || next == to
+			}
+			order := &Order{Status: from}
+			err := order.transition(to)
+			if want && err != nil {
+				t.Errorf("%s -> %s: %v", from, to, err)
+			}
+			if !want {
+				if !errors.Is(err, ErrInvalidTransition) {
+					t.Errorf("%s -> %s: error = %v, ingin %v", from, to, err, ErrInvalidTransition)
+				}
+				if order.Status != from {
+					t.Errorf("%s -> %s: status berubah menjadi %s walaupun ditolak", from, to, order.Status)
+				}
+			}
+		}
+	}
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	order := newTestOrder()
+	steps := []struct {
+		name string
+		step func() error
+		want OrderStatus
+	}{
+		{"konfirmasi", order.Confirm, StatusConfirmed},
+		{"bayar", order.MarkPaid, StatusPaid},
+		{"proses", order.StartProcessing, StatusProcessing},
+		{"selesai", order.Complete, StatusCompleted},
+	}
+	for _, s := range steps {
+		if err := s.step(); err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		if order.Status != s.want {
+			t.Fatalf("%s: status = %s, ingin %s", s.name, order.Status, s.want)
+		}
+	}
+	if err := order.Cancel(ReasonOther); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("pesanan selesai bisa dibatalkan: %v", err)
+	}
+}
+
+func TestCancelRecordsReason(t *testing.T) {
+	at := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	withClock(t, &fixedClock{t: at})
+
+	order := newTestOrder()
+	if err := order.Confirm(); err != nil {
+		t.Fatal(err)
+	}
+	if err := order.Cancel(ReasonCustomerLeft); err != nil {
+		t.Fatal(err)
+	}
+	if order.Status != StatusCancelled || order.CancelReason != ReasonCustomerLeft || !order.CancelledAt.Equal(at) {
+		t.Errorf("status %s, alasan %s, waktu %v", order.Status, order.CancelReason, order.CancelledAt)
+	}
+}
+
+func TestProcessingOrderCannotBeCancelled(t *testing.T) {
+	order := &Order{Status: StatusProcessing}
+	if err := order.Cancel(ReasonKitchenError); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("error = %v, ingin %v", err, ErrInvalidTransition)
+	}
+	if order.CancelReason != "" {
+		t.Errorf("alasan pembatalan tercatat walaupun ditolak: %s", order.CancelReason)
+	}
+}
+
+func TestCancelOrderPermission(t *testing.T) {
+	withRole(t, "kasir")
+	stock := func(n int) *int { return &n }
+	menu := NewInMemoryMenuRepository(map[string]MenuEntry{
+		"nasi goreng": {Name: "nasi goreng", Price: 25000, Category: "makanan", Stock: stock(10)},
+	})
+	log := NewCancelLog(filepath.Join(t.TempDir(), "pembatalan.jsonl"))
+
+	// Sudah dikonfirmasi tetapi belum dibayar: kasir boleh membatalkan dan stok kembali
+	confirmed := newTestOrder()
+	if _, err := commitStock(confirmed, menu); err != nil {
+		t.Fatal(err)
+	}
+	confirmed.Confirm()
+	if err := cancelOrder(confirmed, ReasonCustomerLeft, menu, log); err != nil {
+		t.Fatalf("kasir tidak bisa membatalkan pesanan yang belum dibayar: %v", err)
+	}
+	if entry, _ := menu.Get("nasi goreng"); *entry.Stock != 10 {
+		t.Errorf("stok = %d setelah dibatalkan, ingin 10", *entry.Stock)
+	}
+
+	// Sudah dibayar: perlu void_after_fire
+	paid := newTestOrder()
+	paid.Confirm()
+	paid.MarkPaid()
+	if err := cancelOrder(paid, ReasonCustomerLeft, menu, log); err == nil || !strings.Contains(err.Error(), "void_after_fire") {
+		t.Fatalf("error = %v, ingin memerlukan void_after_fire", err)
+	}
+	withRole(t, "manajer")
+	if err := cancelOrder(paid, ReasonCustomerLeft, menu, log); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// plainOutput mengaktifkan mode teks polos untuk pembaca layar:
+// tanpa simbol dekoratif, kalimat linear dan label eksplisit untuk setiap nilai
+var plainOutput bool
+
+// printMenu menampilkan daftar menu yang dikelompokkan per kategori.
+// Jika filter diisi, hanya item pada kategori tersebut yang ditampilkan.
+func printMenu(w io.Writer, entries []MenuEntry, pricing *compiledPricing, filter string) {
+	printMenuGroups(w, groupMenuByCategory(entries, filter), pricing)
+}
+
+// printMenuPage menampilkan satu halaman menu berisi paling banyak pageSize item,
+// dengan urutan yang sama seperti printMenu. Halaman dihitung dari 0 dan
+// dibatasi ke rentang yang ada; halaman yang benar-benar ditampilkan dikembalikan.
+// pageSize 0 berarti semua item ditampilkan dalam satu halaman.
+func printMenuPage(w io.Writer, entries []MenuEntry, pricing *compiledPricing, filter string, page, pageSize int) int {
+	groups := groupMenuByCategory(entries, filter)
+	total := 0
+	for _, group := range groups {
+		total += len(group.Items)
+	}
+	if pageSize <= 0 || total <= pageSize {
+		printMenuGroups(w, groups, pricing)
+		return 0
+	}
+
+	pages := (total + pageSize - 1) / pageSize
+	page = max(0, min(page, pages-1))
+	start, end := page*pageSize, min((page+1)*pageSize, total)
+
+	// Ambil item pada rentang halaman, kelompok kategori tetap dipertahankan
+	var paged []menuCategory
+	i := 0
+	for _, group := range groups {
+		var items []MenuEntry
+		for _, entry := range group.Items {
+			if i >= start && i < end {
+				items = append(items, entry)
+			}
+			i++
+		}
+		if len(items) > 0 {
+			paged = append(paged, menuCategory{Name: group.Name, Items: items})
+		}
+	}
+	printMenuGroups(w, paged, pricing)
+
+	if plainOutput {
+		fmt.Fprintf(w, "Halaman %d dari %d, menampilkan item %d sampai %d dari %d. Ketik berikut atau sebelum untuk berpindah halaman.\n", page+1, pages, start+1, end, total)
+	} else {
+		fmt.Fprintf(w, "-- Halaman %d/%d (item %d-%d dari %d) | 'berikut' / 'sebelum' --\n", page+1, pages, start+1, end, total)
+	}
+	return page
+}
+
+// printMenuGroups menampilkan kelompok menu yang sudah disusun
+func printMenuGroups(w io.Writer, groups []menuCategory, pricing *compiledPricing) {
+	now := clock.Now()
+
+	if plainOutput {
+		total := 0
+		for _, group := range groups {
+			total += len(group.Items)
+		}
+		fmt.Fprintf(w, "\nMenu berisi %d item dalam %d kategori.\n", total, len(groups))
+		for _, group := range groups {
+			fmt.Fprintf(w, "Kategori %s, %d item.\n", strings.Title(group.Name), len(group.Items))
+			for i, entry := range group.Items {
+				status := ""
+				if entry.soldOut() {
+					status = ", habis"
+				}
+				fmt.Fprintf(w, "Item %d dari %d: %s, harga %s%s.\n", i+1, len(group.Items), strings.Title(entry.Name), pricing.describe(entry, now)+perUnit(entry, " per "), status)
+				if entry.Description != "" {
+					fmt.Fprintf(w, "Deskripsi: %s.\n", strings.TrimRight(entry.Description, "."))
+				}
+				if entry.isBundle() {
+					fmt.Fprintf(w, "Isi paket: %s.\n", describeBundle(entry.Bundle))
+				}
+				if len(entry.Tags) > 0 {
+					fmt.Fprintf(w, "Tag: %s.\n", strings.Join(entry.Tags, ", "))
+				}
+			}
+		}
+		return
+	}
+
+	fmt.Fprintln(w, "\nMenu:")
+	for _, group := range groups {
+		fmt.Fprintf(w, "[%s]\n", strings.Title(group.Name))
+		for _, entry := range group.Items {
+			status := ""
+			if entry.soldOut() {
+				status = " HABIS"
+			}
+			fmt.Fprintf(w, "- %s: %s%s\n", strings.Title(entry.Name), pricing.describe(entry, now)+perUnit(entry, " / "), status)
+			if entry.Description != "" {
+				fmt.Fprintf(w, "  %s\n", entry.Description)
+			}
+			if entry.isBundle() {
+				fmt.Fprintf(w, "  Isi paket: %s\n", describeBundle(entry.Bundle))
+			}
+			if len(entry.Tags) > 0 {
+				fmt.Fprintf(w, "  Tag: %s\n", strings.Join(entry.Tags, ", "))
+			}
+		}
+	}
+}
+
+// printOrderSummary menampilkan item dan total pesanan
+func printOrderSummary(w io.Writer, order *Order) {
+	if plainOutput {
+		fmt.Fprintf(w, "\nPesanan Anda berisi %d item.\n", len(order.Items))
+		for i, item := range order.Items {
+			if len(item.Modifiers) > 0 {
+				fmt.Fprintf(w, "Item %d: %s, jumlah %s, pilihan %s.\n", i+1, item.Name, item.describeQuantity(), describeModifiers(item.Modifiers))
+			} else {
+				fmt.Fprintf(w, "Item %d: %s, jumlah %s.\n", i+1, item.Name, item.describeQuantity())
+			}
+			if item.Note != "" {
+				fmt.Fprintf(w, "Catatan: %s.\n", strings.TrimRight(item.Note, "."))
+			}
+			if len(item.Components) > 0 {
+				fmt.Fprintf(w, "Isi paket: %s.\n", item.BundleContents())
+			}
+		}
+		if order.Discount != nil {
+			fmt.Fprintf(w, "%s: potongan %s.\n", order.Discount.Label(), currentLocale.FormatMoney(order.Discount.Amount))
+		}
+		for _, tax := range order.Taxes {
+			fmt.Fprintf(w, "%s: %s.\n", describeTax(tax), currentLocale.FormatMoney(tax.Amount))
+		}
+		fmt.Fprintf(w, "Total harga: %s.\n", currentLocale.FormatMoney(order.Total))
+		return
+	}
+
+	fmt.Fprintln(w, "\nPesanan Anda:")
+	for i, item := range order.Items {
+		if len(item.Modifiers) > 0 {
+			fmt.Fprintf(w, "%d. %s (%s) [%s]\n", i+1, item.Name, item.quantityLabel(), describeModifiers(item.Modifiers))
+		} else {
+			fmt.Fprintf(w, "%d. %s (%s)\n", i+1, item.Name, item.quantityLabel())
+		}
+		if item.Note != "" {
+			fmt.Fprintf(w, "   Catatan: %s\n", item.Note)
+		}
+		if len(item.Components) > 0 {
+			fmt.Fprintf(w, "   Isi paket: %s\n", item.BundleContents())
+		}
+	}
+	if order.Discount != nil {
+		fmt.Fprintf(w, "%s: -%s\n", order.Discount.Label(), currentLocale.FormatMoney(order.Discount.Amount))
+	}
+	for _, tax := range order.Taxes {
+		fmt.Fprintf(w, "%s: %s\n", describeTax(tax), currentLocale.FormatMoney(tax.Amount))
+	}
+	fmt.Fprintf(w, "Total Harga: %s\n", currentLocale.FormatMoney(order.Total))
+}
+
+// printSeatBills menampilkan tagihan per kursi
+func printSeatBills(w io.Writer, order *Order) {
+	if plainOutput {
+		fmt.Fprintln(w, "\nTagihan per kursi.")
+	} else {
+		fmt.Fprintln(w, "\nTagihan per kursi:")
+	}
+
+	for _, bill := range order.SeatBills() {
+		label := fmt.Sprintf("Kursi %d", bill.Seat)
+		if bill.Seat == 0 {
+			label = "Bersama"
+		}
+
+		if plainOutput {
+			fmt.Fprintf(w, "%s, tagihan %s, subtotal %s.\n", label, currentLocale.FormatMoney(bill.Amount), currentLocale.FormatMoney(bill.Subtotal))
+			for _, item := range bill.Items {
+				fmt.Fprintf(w, "%s: %s, jumlah %s.\n", label, item.Name, item.describeQuantity())
+			}
+			for _, item := range bill.Shared {
+				fmt.Fprintf(w, "%s: %s, jumlah %s, dibagi bersama.\n", label, item.Name, item.describeQuantity())
+			}
+			continue
+		}
+
+		if bill.Amount != bill.Subtotal {
+			fmt.Fprintf(w, "- %s: %s (subtotal %s)\n", label, currentLocale.FormatMoney(bill.Amount), currentLocale.FormatMoney(bill.Subtotal))
+		} else {
+			fmt.Fprintf(w, "- %s: %s\n", label, currentLocale.FormatMoney(bill.Amount))
+		}
+		for _, item := range bill.Items {
+			fmt.Fprintf(w, "    %s (%s)\n", item.Name, item.quantityLabel())
+		}
+		for _, item := range bill.Shared {
+			fmt.Fprintf(w, "    %s (%s, dibagi bersama)\n", item.Name, item.quantityLabel())
+		}
+	}
+}
+
+// printDisplayLine menampilkan item terakhir dan total untuk layar pelanggan di terminal
+func printDisplayLine(w io.Writer, item *MenuItem, total float64) error {
+	var err error
+	if plainOutput {
+		_, err = fmt.Fprintf(w, "Layar pelanggan: %s, jumlah %s, total %s.\n", item.Name, item.describeQuantity(), currentLocale.FormatMoney(total))
+	} else {
+		_, err = fmt.Fprintf(w, "[Layar pelanggan] %s %s | Total %s\n", item.Name, item.quantityLabel(), currentLocale.FormatMoney(total))
+	}
+	return err
+}
+
+// printDisplayTotal menampilkan total untuk layar pelanggan di terminal
+func printDisplayTotal(w io.Writer, total float64) error {
+	var err error
+	if plainOutput {
+		_, err = fmt.Fprintf(w, "Layar pelanggan: total %s.\n", currentLocale.FormatMoney(total))
+	} else {
+		_, err = fmt.Fprintf(w, "[Layar pelanggan] Total %s\n", currentLocale.FormatMoney(total))
+	}
+	return err
+}
+
+// printDisplayChange menampilkan pembayaran dan kembalian untuk layar pelanggan di terminal
+func printDisplayChange(w io.Writer, payment, change float64) error {
+	var err error
+	if plainOutput {
+		_, err = fmt.Fprintf(w, "Layar pelanggan: dibayar %s, kembalian %s.\n", currentLocale.FormatMoney(payment), currentLocale.FormatMoney(change))
+	} else {
+		_, err = fmt.Fprintf(w, "[Layar pelanggan] Bayar %s | Kembali %s\n", currentLocale.FormatMoney(payment), currentLocale.FormatMoney(change))
+	}
+	return err
+}
+
+// printWarnings menampilkan peringatan validasi pesanan
+func printWarnings(w io.Writer, warnings []ValidationWarning) {
+	if plainOutput {
+		fmt.Fprintf(w, "\nAda %d peringatan.\n", len(warnings))
+		for i, warning := range warnings {
+			fmt.Fprintf(w, "Peringatan %d: %s.\n", i+1, warning.Message)
+		}
+		return
+	}
+
+	fmt.Fprintln(w, "\nPeringatan:")
+	for _, warning := range warnings {
+		fmt.Fprintf(w, "- %s\n", warning.Message)
+	}
+}
+
+// printTickets menampilkan pesanan yang masih terbuka per meja
+func printTickets(w io.Writer, tickets []*Ticket, active string) {
+	if plainOutput {
+		fmt.Fprintf(w, "\nAda %d meja dengan pesanan terbuka.\n", len(tickets))
+		for _, ticket := range tickets {
+			status := ""
+			if ticket.Table == active {
+				status = ", sedang aktif"
+			}
+			fmt.Fprintf(w, "Meja %s: %d item, total %s%s.\n", ticket.Table, len(ticket.Order.Items), currentLocale.FormatMoney(ticket.Order.Total), status)
+		}
+		return
+	}
+
+	fmt.Fprintln(w, "\nMeja terbuka:")
+	for _, ticket := range tickets {
+		marker := " "
+		if ticket.Table == active {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s Meja %s: %d item, %s\n", marker, ticket.Table, len(ticket.Order.Items), currentLocale.FormatMoney(ticket.Order.Total))
+	}
+}
+
+// printSplitBills menampilkan bagian tagihan setiap pembayar
+func printSplitBills(w io.Writer, bills []SplitBill) {
+	if plainOutput {
+		fmt.Fprintf(w, "\nTagihan dibagi untuk %d pembayar.\n", len(bills))
+	} else {
+		fmt.Fprintln(w, "\nBagi tagihan:")
+	}
+
+	for _, bill := range bills {
+		if plainOutput {
+			fmt.Fprintf(w, "Pembayar %d, tagihan %s.\n", bill.Payer, currentLocale.FormatMoney(bill.Amount))
+			for _, item := range bill.Items {
+				fmt.Fprintf(w, "Pembayar %d: %s, jumlah %s.\n", bill.Payer, item.Name, item.describeQuantity())
+			}
+			continue
+		}
+
+		fmt.Fprintf(w, "- Pembayar %d: %s\n", bill.Payer, currentLocale.FormatMoney(bill.Amount))
+		for _, item := range bill.Items {
+			fmt.Fprintf(w, "    %s (%s)\n", item.Name, item.quantityLabel())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// paletteCommand merepresentasikan satu aksi yang bisa dicari lewat palet perintah
+type paletteCommand struct {
+	Name        string
+	Usage       string
+	Description string
+}
+
+// paletteCommands berisi semua aksi yang tersedia di prompt kasir
+var paletteCommands = []paletteCommand{
+	{Name: "tambah item", Usage: "<nama item>", Description: "Menambahkan item ke pesanan, lalu menanyakan jumlah"},
+	{Name: "input cepat", Usage: "2x nasi goreng; 1x ayam bakar; bayar 100000", Description: "Menambahkan beberapa item dan membayar dalam satu baris"},
+	{Name: "pindah meja", Usage: "meja [nomor]", Description: "Menampilkan meja yang terbuka, atau membuka/berpindah ke pesanan meja lain"},
+	{Name: "gabung meja", Usage: "gabung <nomor meja>", Description: "Menggabungkan pesanan meja lain ke meja aktif agar dibayar bersama"},
+	{Name: "tahan pesanan", Usage: "tahan", Description: "Menyimpan pesanan yang belum selesai agar bisa dilanjutkan nanti, juga setelah program ditutup"},
+	{Name: "daftar pesanan ditahan", Usage: "ditahan", Description: "Menampilkan pesanan yang sedang ditahan"},
+	{Name: "lanjutkan pesanan", Usage: "lanjut <kode pesanan|ID>", Description: "Melanjutkan pesanan yang ditahan di meja aktif"},
+	{Name: "batalkan pesanan", Usage: "batal [kode alasan]", Description: "Membatalkan pesanan meja aktif dengan kode alasan, atau menampilkan daftar alasan"},
+	{Name: "diskon pesanan", Usage: "diskon <persen>% | diskon <nominal> | diskon hapus", Description: "Memberi atau menghapus potongan untuk seluruh pesanan"},
+	{Name: "hapus item", Usage: "hapus <nomor>", Description: "Menghapus item dari pesanan sesuai nomor pada ringkasan pesanan"},
+	{Name: "ubah jumlah", Usage: "ubah <nomor> <jumlah>", Description: "Mengubah jumlah item pesanan sesuai nomor pada ringkasan pesanan"},
+	{Name: "catatan item", Usage: "catatan <nomor> [teks]", Description: "Mengganti atau menghapus catatan dapur pada item pesanan"},
+	{Name: "halaman menu", Usage: "berikut | sebelum", Description: "Menampilkan halaman menu berikutnya atau sebelumnya"},
+	{Name: "filter kategori", Usage: "kategori [nama|semua]", Description: "Menampilkan daftar kategori atau hanya menu pada satu kategori"},
+	{Name: "filter tag", Usage: "tag [nama|tanpa nama]", Description: "Menampilkan item dengan tag alergen/diet, misalnya vegetarian atau tanpa kacang"},
+	{Name: "admin menu", Usage: "admin", Description: "Menambah, mengubah harga, atau menghapus item menu"},
+	{Name: "feature flag", Usage: "fitur [nama on|off|persen]", Description: "Menampilkan atau mengubah feature flag saat program berjalan"},
+	{Name: "alur pemrosesan", Usage: "pipeline", Description: "Menampilkan tahap pemrosesan pesanan beserta isi antrian dan statistik encoder sesi ini"},
+	{Name: "status voucher wifi", Usage: "wifi", Description: "Menampilkan jumlah voucher Wi-Fi yang tersisa dan sudah diberikan"},
+	{Name: "selesai", Usage: "selesai", Description: "Menyelesaikan pesanan dan lanjut ke pembayaran"},
+	{Name: "palet perintah", Usage: "? [kata kunci]", Description: "Mencari aksi yang tersedia"},
+}
+
+// isPaletteQuery mengecek apakah input membuka palet perintah
+func isPaletteQuery(input string) bool {
+	return strings.HasPrefix(input, "?")
+}
+
+// fuzzyScore mengembalikan skor kecocokan query terhadap text (makin kecil makin cocok).
+// Query cocok jika semua hurufnya muncul berurutan di text.
+func fuzzyScore(query, text string) (int, bool) {
+	query = strings.ToLower(strings.ReplaceAll(query, " ", ""))
+	text = strings.ToLower(text)
+	if query == "" {
+		return 0, true
+	}
+
+	score, last := 0, -1
+	qi := 0
+	for ti, c := range text {
+		if qi < len(query) && byte(c) == query[qi] {
+			if last >= 0 {
+				score += ti - last - 1
+			}
+			last = ti
+			qi++
+		}
+	}
+	return score, qi == len(query)
+}
+
+// searchPalette mencari perintah yang cocok dengan query, diurutkan dari yang paling cocok
+func searchPalette(query string) []paletteCommand {
+	type scored struct {
+		cmd   paletteCommand
+		score int
+	}
+
+	var matches []scored
+	for _, cmd := range paletteCommands {
+		best, found := 0, false
+		for _, field := range []string{cmd.Name, cmd.Description} {
+			if s, ok := fuzzyScore(query, field); ok && (!found || s < best) {
+				best, found = s, true
+			}
+		}
+		if found {
+			matches = append(matches, scored{cmd, best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+	result := make([]paletteCommand, len(matches))
+	for i, m := range matches {
+		result[i] = m.cmd
+	}
+	return result
+}
+
+// showPalette menampilkan hasil pencarian palet perintah
+func showPalette(input string) {
+	query := strings.TrimSpace(strings.TrimPrefix(input, "?"))
+	matches := searchPalette(query)
+	if len(matches) == 0 {
+		fmt.Printf("Tidak ada perintah yang cocok dengan '%s'\n", query)
+		return
+	}
+
+	if plainOutput {
+		fmt.Printf("\nDitemukan %d perintah.\n", len(matches))
+		for i, cmd := range matches {
+			fmt.Printf("Perintah %d: %s. Cara pakai: %s. %s.\n", i+1, cmd.Name, cmd.Usage, cmd.Description)
+		}
+		return
+	}
+
+	fmt.Println("\nPerintah:")
+	for _, cmd := range matches {
+		fmt.Printf("- %s: %s\n  %s\n", cmd.Name, cmd.Usage, cmd.Description)
+	}
+}
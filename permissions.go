@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Permission hak akses untuk satu jenis aksi
+type Permission string
+
+const (
+	PermTakeOrder          Permission = "take_order"
+	PermApplyDiscountOverX Permission = "apply_discount_over_x"
+	PermVoidAfterFire      Permission = "void_after_fire"
+	PermViewReports        Permission = "view_reports"
+	PermEditMenu           Permission = "edit_menu"
+	PermIssueRefund        Permission = "issue_refund"
+	PermManageFeatures     Permission = "manage_features"
+)
+
+// allPermissions daftar semua permission yang dikenal
+var allPermissions = []Permission{
+	PermTakeOrder, PermApplyDiscountOverX, PermVoidAfterFire, PermViewReports,
+	PermEditMenu, PermIssueRefund, PermManageFeatures,
+}
+
+// defaultRoles role bawaan jika tidak ada file role
+var defaultRoles = map[string][]Permission{
+	"kasir":   {PermTakeOrder},
+	"manajer": allPermissions,
+}
+
+var (
+	// roles matriks role ke permission yang sedang dipakai
+	roles = defaultRoles
+	// currentRole role operator yang sedang login
+	currentRole string
+)
+
+// loadRoles membaca matriks role dari file JSON, misalnya
+// {"supervisor": ["take_order", "view_reports"]}
+func loadRoles(path string) (map[string][]Permission, error) {
+	if path == "" {
+		return defaultRoles, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca role: %w", err)
+	}
+
+	var loaded map[string][]Permission
+	if err := json.Unmarshal(b, &loaded); err != nil {
+		return nil, fmt.Errorf("format role tidak valid: %w", err)
+	}
+	for role, perms := range loaded {
+		for _, p := range perms {
+			if !knownPermission(p) {
+				return nil, fmt.Errorf("permission '%s' pada role '%s' tidak dikenal", p, role)
+			}
+		}
+	}
+	return loaded, nil
+}
+
+// knownPermission mengecek apakah permission ada di daftar
+func knownPermission(p Permission) bool {
+	for _, known := range allPermissions {
+		if known == p {
+			return true
+		}
+	}
+	return false
+}
+
+// setRole memilih role operator yang sedang berjalan
+func setRole(role string) error {
+	if _, exists := roles[role]; !exists {
+		return fmt.Errorf("role '%s' tidak terdaftar", role)
+	}
+	currentRole = role
+	return nil
+}
+
+// hasPermission mengecek apakah role operator saat ini memiliki permission
+func hasPermission(p Permission) bool {
+	for _, granted := range roles[currentRole] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// requirePermission mengembalikan error jika role operator tidak memiliki permission
+func requirePermission(p Permission) error {
+	if !hasPermission(p) {
+		return fmt.Errorf("role '%s' tidak memiliki izin %s", currentRole, p)
+	}
+	return nil
+}
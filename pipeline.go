@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// pipelineStage satu tahap yang dilalui pesanan setelah kasir mengetik "selesai"
+type pipelineStage struct {
+	Name   string
+	Detail string
+}
+
+// describePipeline menyusun tahap pemrosesan pesanan sesuai urutan di main
+// berdasarkan konfigurasi yang sudah dimuat
+func describePipeline(cfg *Config, processor *RestaurantOrderProcessor) []pipelineStage {
+	enabled := func(value, off string) string {
+		if value == "" {
+			return off
+		}
+		return value
+	}
+
+	pricing := cfg.PricingEngine
+	flagsMu.RLock()
+	flag, exists := featureFlags[flagPricingEngine]
+	flagsMu.RUnlock()
+	if exists && flag.Enabled && flag.Variant != "" {
+		pricing += fmt.Sprintf(", varian '%s' lewat feature flag", flag.Variant)
+	}
+
+	return []pipelineStage{
+		{Name: "harga", Detail: fmt.Sprintf("engine %s, %d tarif pajak", pricing, len(taxRates))},
+		{Name: "validasi", Detail: "pesanan kosong atau harga negatif ditolak, peringatan dikonfirmasi kasir"},
+		{Name: "stok", Detail: "webhook stok menipis: " + enabled(cfg.LowStockWebhook, "nonaktif")},
+		{Name: "hook " + string(HookOrderConfirmed), Detail: describeHooks(HookOrderConfirmed)},
+		{Name: "nomor antrian", Detail: enabled(cfg.QueueFile, "nonaktif")},
+		{Name: "processor", Detail: fmt.Sprintf("%d slot antrian, timeout %s, satu goroutine per pesanan; status dibayar -> diproses -> selesai", processor.QueueCapacity(), processor.Timeout())},
+		{Name: "voucher Wi-Fi", Detail: enabled(cfg.WifiVoucherPath, "nonaktif")},
+		{Name: "struk", Detail: enabled(cfg.ReceiptTemplatePath, "template bawaan")},
+		{Name: "hook " + string(HookPaymentCompleted), Detail: describeHooks(HookPaymentCompleted)},
+	}
+}
+
+// describeHooks jumlah hook yang terdaftar pada satu titik
+func describeHooks(point HookPoint) string {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	return fmt.Sprintf("%d perintah shell, %d callback Go", len(shellHooks[point]), len(funcHooks[point]))
+}
+
+// runPipeline menjalankan perintah "pipeline": memuat konfigurasi seperti mode kasir
+// lalu mencetak tahap yang akan dilalui pesanan. Isi antrian, latensi per tahap dan
+// statistik encoder hanya ada selama sesi kasir berjalan; ketik "pipeline" di prompt kasir untuk melihatnya.
+func runPipeline(args []string, w io.Writer) error {
+	cfg, err := parseConfig(args)
+	if err != nil {
+		return err
+	}
+	if err := SetPricingEngine(cfg.PricingEngine); err != nil {
+		return err
+	}
+	if err := loadFeatureFlags(cfg.FlagsPath); err != nil {
+		return err
+	}
+	if err := loadHooks(cfg.HooksPath); err != nil {
+		return err
+	}
+	if taxRates, err = loadTaxRates(cfg.TaxRatesPath); err != nil {
+		return err
+	}
+
+	processor := NewRestaurantOrderProcessor()
+	defer processor.Close()
+
+	printPipeline(w, describePipeline(cfg, processor), cfg.Plain)
+	return nil
+}
+
+// printPipeline mencetak tahap pemrosesan pesanan
+func printPipeline(w io.Writer, stages []pipelineStage, plain bool) {
+	if plain {
+		fmt.Fprintf(w, "Pesanan melewati %d tahap.\n", len(stages))
+		for i, stage := range stages {
+			fmt.Fprintf(w, "Tahap %d, %s: %s.\n", i+1, stage.Name, stage.Detail)
+		}
+		return
+	}
+
+	fmt.Fprintln(w, "Alur pemrosesan pesanan:")
+	for i, stage := range stages {
+		if i > 0 {
+			fmt.Fprintln(w, "    |")
+		}
+		fmt.Fprintf(w, "%2d. %-24s %s\n", i+1, stage.Name, stage.Detail)
+	}
+}
+
+// printPipelineStats mencetak isi antrian processor, latensi per tahap processor
+// dan statistik encoder sesi ini
+func printPipelineStats(w io.Writer, processor *RestaurantOrderProcessor, plain bool) {
+	stats := CurrentEncoderStats()
+	latency := processor.Stats()
+	stages := []struct {
+		name  string
+		stats StageStats
+	}{
+		{"tunggu antrian", latency.Queue},
+		{"encode", latency.Encode},
+		{"proses", latency.Process},
+	}
+
+	if plain {
+		fmt.Fprintf(w, "Antrian processor terisi %d dari %d slot, %d pesanan timeout.\n", processor.QueueDepth(), processor.QueueCapacity(), latency.Timeouts)
+		for _, stage := range stages {
+			fmt.Fprintf(w, "Tahap %s: %d pesanan, rata-rata %s, paling lama %s.\n", stage.name, stage.stats.Count, stage.stats.Average(), stage.stats.Max)
+		}
+		fmt.Fprintf(w, "Encoder: %d pesanan, %d buffer baru dari pool, %d byte.\n", stats.Encoded, stats.BufferAlloc, stats.Bytes)
+		return
+	}
+
+	fmt.Fprintln(w, "\nStatistik sesi:")
+	fmt.Fprintf(w, "- antrian processor: %d/%d slot terisi, %d timeout\n", processor.QueueDepth(), processor.QueueCapacity(), latency.Timeouts)
+	for _, stage := range stages {
+		fmt.Fprintf(w, "- %-15s %d pesanan, rata-rata %s, maks %s\n", stage.name+":", stage.stats.Count, stage.stats.Average(), stage.stats.Max)
+	}
+	fmt.Fprintf(w, "- encoder: %d pesanan, %d buffer baru dari pool, %d byte\n", stats.Encoded, stats.BufferAlloc, stats.Bytes)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Totals hasil perhitungan harga sebuah pesanan
+type Totals struct {
+	Subtotal float64
+	Total    float64
+}
+
+// PricingEngine menghitung total pesanan. Implementasi bawaan bisa diganti
+// dengan aturan khusus (misalnya aturan franchise) yang ditulis di package ini
+// dan didaftarkan lewat registerPricingEngine dari fungsi init.
+type PricingEngine interface {
+	Price(order *Order) Totals
+}
+
+// defaultPricingEngine menjumlahkan harga dikali jumlah setiap item
+type defaultPricingEngine struct{}
+
+// Price menghitung total pesanan dengan aturan bawaan
+func (defaultPricingEngine) Price(order *Order) Totals {
+	var t Totals
+	for _, item := range order.Items {
+		t.Subtotal += item.LineTotal()
+	}
+	t.Total = t.Subtotal
+	return t
+}
+
+var (
+	pricingMu      sync.RWMutex
+	pricingEngines = map[string]PricingEngine{
+		"default": defaultPricingEngine{},
+	}
+	pricingEngine PricingEngine = defaultPricingEngine{}
+)
+
+// registerPricingEngine mendaftarkan engine dengan nama agar bisa dipilih lewat flag -pricing
+func registerPricingEngine(name string, engine PricingEngine) {
+	pricingMu.Lock()
+	defer pricingMu.Unlock()
+	pricingEngines[name] = engine
+}
+
+// SetPricingEngine memilih engine yang sudah terdaftar sebagai engine aktif
+func SetPricingEngine(name string) error {
+	pricingMu.Lock()
+	defer pricingMu.Unlock()
+	engine, exists := pricingEngines[name]
+	if !exists {
+		return fmt.Errorf("pricing engine '%s' tidak terdaftar", name)
+	}
+	pricingEngine = engine
+	return nil
+}
+
+// pricingEngineFor mengembalikan engine untuk pesanan tertentu. Jika flag
+// pricing_engine aktif untuk pesanan ini, engine varian dari flag yang dipakai.
+func pricingEngineFor(order *Order) PricingEngine {
+	if flag, on := FeatureEnabled(flagPricingEngine, order.TrackingCode); on && flag.Variant != "" {
+		pricingMu.RLock()
+		engine, exists := pricingEngines[flag.Variant]
+		pricingMu.RUnlock()
+		if exists {
+			return engine
+		}
+	}
+	return currentPricingEngine()
+}
+
+// currentPricingEngine mengembalikan engine yang sedang aktif
+func currentPricingEngine() PricingEngine {
+	pricingMu.RLock()
+	defer pricingMu.RUnlock()
+	return pricingEngine
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PricingRule aturan penyesuaian harga berdasarkan waktu, misalnya
+// {"name": "happy hour", "category": "minuman", "discount_percent": 20,
+// "available": [{"from": "15:00", "until": "17:00"}]}.
+// Aturan berlaku untuk item pada Items atau Category; jika keduanya kosong,
+// aturan berlaku untuk semua item.
+type PricingRule struct {
+	Name            string               `json:"name"`
+	Items           []string             `json:"items,omitempty"`
+	Category        string               `json:"category,omitempty"`
+	DiscountPercent float64              `json:"discount_percent"`
+	Available       []AvailabilityWindow `json:"available"`
+}
+
+var (
+	pricingRulesMu sync.RWMutex
+	// pricingRules aturan harga aktif, dibaca dari file -pricing-rules
+	pricingRules []PricingRule
+)
+
+// loadPricingRules membaca aturan harga dari file JSON
+func loadPricingRules(path string) error {
+	if path == "" {
+		return nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gagal membaca aturan harga: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var rules []PricingRule
+	if err := dec.Decode(&rules); err != nil {
+		return fmt.Errorf("format aturan harga tidak valid: %w", err)
+	}
+	for i := range rules {
+		rule := &rules[i]
+		rule.Category = strings.ToLower(strings.TrimSpace(rule.Category))
+		for j, name := range rule.Items {
+			rule.Items[j] = strings.ToLower(strings.Join(strings.Fields(name), " "))
+		}
+		if err := validatePricingRule(*rule); err != nil {
+			return fmt.Errorf("aturan harga ke-%d: %w", i+1, err)
+		}
+	}
+
+	pricingRulesMu.Lock()
+	defer pricingRulesMu.Unlock()
+	pricingRules = rules
+	pricingRulesVersion++
+	return nil
+}
+
+// validatePricingRule memeriksa satu aturan harga
+func validatePricingRule(rule PricingRule) error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return fmt.Errorf("nama wajib diisi")
+	}
+	if rule.DiscountPercent <= 0 || rule.DiscountPercent > 100 {
+		return fmt.Errorf("discount_percent '%s' harus antara 0 dan 100", rule.Name)
+	}
+	if len(rule.Available) == 0 {
+		return fmt.Errorf("jadwal '%s' wajib diisi", rule.Name)
+	}
+	if err := validateAvailability(rule.Available); err != nil {
+		return fmt.Errorf("jadwal '%s': %w", rule.Name, err)
+	}
+	return nil
+}
+
+// appliesTo mengecek apakah aturan berlaku untuk item menu
+func (r PricingRule) appliesTo(entry MenuEntry) bool {
+	if len(r.Items) == 0 && r.Category == "" {
+		return true
+	}
+	if r.Category != "" && r.Category == entry.Category {
+		return true
+	}
+	for _, name := range r.Items {
+		if name == entry.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// compiledRule aturan harga dengan jadwal yang sudah diubah ke menit sejak tengah malam
+type compiledRule struct {
+	rule    *PricingRule
+	windows [][2]int
+}
+
+// activeAt mengecek apakah aturan berlaku pada waktu t
+func (c compiledRule) activeAt(t time.Time) bool {
+	minute := t.Hour()*60 + t.Minute()
+	for _, w := range c.windows {
+		from, until := w[0], w[1]
+		if from < until && minute >= from && minute < until {
+			return true
+		}
+		// Rentang melewati tengah malam
+		if from > until && (minute >= from || minute < until) {
+			return true
+		}
+	}
+	return false
+}
+
+// compiledPricing aturan harga yang sudah dicocokkan ke setiap item menu,
+// diurutkan dari diskon terbesar. Dibuat ulang hanya jika versi menu atau
+// aturan harga berubah, bukan setiap kali harga dihitung.
+type compiledPricing struct {
+	menuVersion  uint64
+	rulesVersion uint64
+	items        map[string][]compiledRule
+}
+
+// pricingCacheSize jumlah versi menu yang hasil kompilasinya disimpan. Tiket yang
+// masih memakai snapshot menu lama tidak mengompilasi ulang setiap kali menu live berubah.
+const pricingCacheSize = 8
+
+var (
+	// pricingRulesVersion bertambah setiap kali aturan harga dimuat ulang
+	pricingRulesVersion uint64
+	// pricingCache hasil kompilasi per versi menu, dipakai ulang selama versi aturan harga sama
+	pricingCache = make(map[uint64]*compiledPricing)
+)
+
+// pricingFor mengembalikan aturan harga yang sudah dikompilasi untuk menu
+func pricingFor(menu MenuRepository) *compiledPricing {
+	version := menu.Version()
+	pricingRulesMu.RLock()
+	cached, rulesVersion := pricingCache[version], pricingRulesVersion
+	pricingRulesMu.RUnlock()
+	if cached != nil && cached.rulesVersion == rulesVersion {
+		return cached
+	}
+
+	pricingRulesMu.Lock()
+	defer pricingRulesMu.Unlock()
+	compiled := compilePricing(menu, pricingRules, pricingRulesVersion)
+	// Buang hasil untuk aturan harga lama, lalu versi menu tertua jika cache penuh
+	var oldest uint64
+	for v, c := range pricingCache {
+		if c.rulesVersion != pricingRulesVersion {
+			delete(pricingCache, v)
+			continue
+		}
+		if oldest == 0 || v < oldest {
+			oldest = v
+		}
+	}
+	if len(pricingCache) >= pricingCacheSize {
+		delete(pricingCache, oldest)
+	}
+	pricingCache[version] = compiled
+	return compiled
+}
+
+// compilePricing mencocokkan aturan harga ke setiap item menu
+func compilePricing(menu MenuRepository, source []PricingRule, rulesVersion uint64) *compiledPricing {
+	compiled := &compiledPricing{
+		menuVersion:  menu.Version(),
+		rulesVersion: rulesVersion,
+		items:        make(map[string][]compiledRule),
+	}
+	rules := make([]compiledRule, 0, len(source))
+	for i := range source {
+		c := compiledRule{rule: &source[i]}
+		for _, w := range source[i].Available {
+			// Format jam sudah divalidasi saat aturan dimuat
+			from, _ := parseClockTime(w.From)
+			until, _ := parseClockTime(w.Until)
+			c.windows = append(c.windows, [2]int{from, until})
+		}
+		rules = append(rules, c)
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].rule.DiscountPercent > rules[j].rule.DiscountPercent })
+	for _, entry := range menu.List() {
+		for _, c := range rules {
+			if c.rule.appliesTo(entry) {
+				compiled.items[entry.Name] = append(compiled.items[entry.Name], c)
+			}
+		}
+	}
+	return compiled
+}
+
+// price menghitung harga item menu pada waktu t setelah aturan harga.
+// Jika beberapa aturan berlaku, diskon terbesar yang dipakai.
+func (p *compiledPricing) price(entry MenuEntry, t time.Time) (float64, *PricingRule) {
+	for _, c := range p.items[entry.Name] {
+		if c.activeAt(t) {
+			return currentLocale.Round(entry.Price * (100 - c.rule.DiscountPercent) / 100), c.rule
+		}
+	}
+	return entry.Price, nil
+}
+
+// describe memformat harga item untuk daftar menu, termasuk aturan yang berlaku
+func (p *compiledPricing) describe(entry MenuEntry, t time.Time) string {
+	price, rule := p.price(entry, t)
+	if rule == nil {
+		return currentLocale.FormatMoney(price)
+	}
+	return fmt.Sprintf("%s (%s -%g%%, normal %s)", currentLocale.FormatMoney(price), rule.Name, rule.DiscountPercent, currentLocale.FormatMoney(entry.Price))
+}
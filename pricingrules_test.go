@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// withPricingRules memasang aturan harga untuk satu test lalu mengembalikan aturan sebelumnya
+func withPricingRules(tb testing.TB, rules []PricingRule) {
+	tb.Helper()
+	pricingRulesMu.Lock()
+	previous := pricingRules
+	pricingRules = rules
+	pricingRulesVersion++
+	pricingRulesMu.Unlock()
+	tb.Cleanup(func() {
+		pricingRulesMu.Lock()
+		pricingRules = previous
+		pricingRulesVersion++
+		pricingRulesMu.Unlock()
+	})
+}
+
+// benchmarkMenu menu dengan n item yang terbagi ke beberapa kategori
+func benchmarkMenu(n int) *InMemoryMenuRepository {
+	entries := make(map[string]MenuEntry, n)
+	categories := []string{"makanan", "minuman", "camilan", "paket"}
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("item %d", i)
+		entries[name] = MenuEntry{Name: name, Price: float64(10000 + i*500), Category: categories[i%len(categories)]}
+	}
+	return NewInMemoryMenuRepository(entries)
+}
+
+// benchmarkRules aturan harga per kategori dengan beberapa jadwal
+var benchmarkRules = []PricingRule{
+	{Name: "happy hour", Category: "minuman", DiscountPercent: 20, Available: []AvailabilityWindow{{From: "15:00", Until: "17:00"}}},
+	{Name: "sarapan", Category: "makanan", DiscountPercent: 10, Available: []AvailabilityWindow{{From: "06:00", Until: "10:00"}}},
+	{Name: "malam", DiscountPercent: 5, Available: []AvailabilityWindow{{From: "22:00", Until: "02:00"}}},
+	{Name: "camilan sore", Category: "camilan", DiscountPercent: 15, Available: []AvailabilityWindow{{From: "14:00", Until: "16:00"}, {From: "19:00", Until: "21:00"}}},
+}
+
+func TestPricingForCachesPerMenuVersion(t *testing.T) {
+	withPricingRules(t, benchmarkRules)
+	live := benchmarkMenu(4)
+	old := live.Snapshot()
+	if err := live.Add(MenuEntry{Name: "item baru", Price: 5000, Category: "minuman"}); err != nil {
+		t.Fatal(err)
+	}
+
+	oldPricing, livePricing := pricingFor(old), pricingFor(live)
+	if oldPricing == livePricing {
+		t.Fatal("versi menu berbeda memakai hasil kompilasi yang sama")
+	}
+	// Bergantian antar versi tidak mengompilasi ulang
+	if pricingFor(old) != oldPricing || pricingFor(live) != livePricing {
+		t.Error("hasil kompilasi tidak dipakai ulang per versi menu")
+	}
+	if _, exists := livePricing.items["item baru"]; !exists {
+		t.Error("aturan harga tidak dicocokkan ke item baru")
+	}
+
+	withPricingRules(t, nil)
+	if pricingFor(live) == livePricing {
+		t.Error("hasil kompilasi tidak dibuat ulang setelah aturan harga berubah")
+	}
+}
+
+func TestCompiledPricingPrice(t *testing.T) {
+	withPricingRules(t, benchmarkRules)
+	menu := benchmarkMenu(4)
+	pricing := pricingFor(menu)
+	// item 1 minuman seharga Rp10.500
+	drink, _ := menu.Get("item 1")
+
+	tests := []struct {
+		at   string
+		want float64
+	}{
+		{"15:30", 8400},
+		{"17:00", 10500},
+		{"23:00", 9975},
+		{"01:59", 9975},
+	}
+	for _, tt := range tests {
+		at, _ := time.Parse("15:04", tt.at)
+		if got, _ := pricing.price(drink, at); got != tt.want {
+			t.Errorf("harga pukul %s = %v, ingin %v", tt.at, got, tt.want)
+		}
+	}
+}
+
+// BenchmarkCheckout membandingkan menghitung harga satu pesanan dengan aturan harga
+// yang sudah dikompilasi dan yang dikompilasi ulang untuk setiap item
+func BenchmarkCheckout(b *testing.B) {
+	withPricingRules(b, benchmarkRules)
+	menu := benchmarkMenu(200)
+	entries := menu.List()[:20]
+	at := time.Date(2024, 1, 1, 15, 30, 0, 0, time.UTC)
+
+	checkout := func(b *testing.B, pricing func() *compiledPricing) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			order := &Order{}
+			for _, entry := range entries {
+				price, _ := pricing().price(entry, at)
+				order.AddItem(entry.Name, price, 1)
+			}
+			order.calculateTotal()
+		}
+	}
+
+	b.Run("compiled", func(b *testing.B) {
+		checkout(b, func() *compiledPricing { return pricingFor(menu) })
+	})
+	b.Run("uncompiled", func(b *testing.B) {
+		checkout(b, func() *compiledPricing { return compilePricing(menu, benchmarkRules, 0) })
+	})
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrProcessorClosed dikembalikan saat pesanan dikirim ke processor yang sudah ditutup
+var ErrProcessorClosed = errors.New("processor sudah ditutup")
+
+// ErrProcessTimeout dikembalikan saat pesanan tidak mendapat giliran diproses sebelum timeout
+var ErrProcessTimeout = errors.New("timeout memproses pesanan")
+
+// RestaurantOrderProcessor implementasi dari OrderProcessor.
+// Channel orders dipakai sebagai antrian berkapasitas tetap dan hanya ditutup lewat Close.
+type RestaurantOrderProcessor struct {
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	closed  bool
+	orders  chan *Order
+	timeout time.Duration
+
+	statsMu sync.Mutex
+	stats   ProcessorStats
+}
+
+// StageStats latensi satu tahap processor
+type StageStats struct {
+	Count uint64
+	Total time.Duration
+	Max   time.Duration
+}
+
+// record mencatat satu latensi pada tahap
+func (s *StageStats) record(d time.Duration) {
+	s.Count++
+	s.Total += d
+	s.Max = max(s.Max, d)
+}
+
+// Average rata-rata latensi tahap, nol jika belum ada pesanan
+func (s StageStats) Average() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// ProcessorStats latensi per tahap processor sejak processor dibuat
+type ProcessorStats struct {
+	Queue    StageStats // menunggu slot antrian
+	Encode   StageStats // encode ringkasan pesanan
+	Process  StageStats // dari mulai diproses sampai selesai, termasuk encode
+	Timeouts uint64     // pesanan yang tidak mendapat slot sebelum timeout
+}
+
+// OrderHandle mewakili satu pesanan yang sedang diproses
+type OrderHandle struct {
+	done  chan struct{}
+	order *Order
+	err   error
+}
+
+// Done ditutup saat pesanan selesai diproses, berhasil maupun gagal
+func (h *OrderHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Result menunggu pesanan selesai lalu mengembalikan hasilnya
+func (h *OrderHandle) Result() (*Order, error) {
+	<-h.done
+	return h.order, h.err
+}
+
+var _ OrderProcessor = (*RestaurantOrderProcessor)(nil)
+
+// NewRestaurantOrderProcessor membuat processor baru
+func NewRestaurantOrderProcessor() *RestaurantOrderProcessor {
+	return &RestaurantOrderProcessor{
+		orders:  make(chan *Order, 10), // buffered channel
+		timeout: 5 * time.Second,
+	}
+}
+
+// ValidateOrder memvalidasi pesanan dan mencatat peringatannya pada pesanan
+func (p *RestaurantOrderProcessor) ValidateOrder(order *Order) ([]ValidationWarning, error) {
+	warnings, err := validateOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	order.Warnings = warnings
+	return warnings, nil
+}
+
+// Process memproses pesanan dan menunggu sampai selesai
+func (p *RestaurantOrderProcessor) Process(order *Order) error {
+	handle, err := p.ProcessOrder(order)
+	if err != nil {
+		return err
+	}
+	_, err = handle.Result()
+	return err
+}
+
+// ProcessOrder memvalidasi lalu memproses pesanan di goroutine terpisah dan
+// mengembalikan handle untuk menunggu hasil pesanan tersebut
+func (p *RestaurantOrderProcessor) ProcessOrder(order *Order) (*OrderHandle, error) {
+	warnings, err := p.ValidateOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	if len(warnings) > 0 && !order.WarningsAcknowledged {
+		return nil, ErrUnacknowledgedWarnings
+	}
+	if err := order.canTransition(StatusProcessing); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil, ErrProcessorClosed
+	}
+	p.wg.Add(1)
+	p.mu.Unlock()
+
+	handle := &OrderHandle{done: make(chan struct{})}
+	queued := time.Now()
+	go func() {
+		defer p.wg.Done()
+		defer close(handle.done)
+
+		select {
+		case <-time.After(p.timeout):
+			handle.err = ErrProcessTimeout
+			p.recordStats(func(s *ProcessorStats) { s.Timeouts++ })
+		case p.orders <- order:
+			// Lepaskan slot antrian setelah selesai
+			defer func() { <-p.orders }()
+			started := time.Now()
+
+			// Pesanan yang timeout tetap berstatus dibayar dan bisa dikirim ulang
+			if handle.err = order.StartProcessing(); handle.err != nil {
+				return
+			}
+			order.Encrypted = encodeOrderSummary(order)
+			encoded := time.Now()
+			handle.err = order.Complete()
+			handle.order = order
+
+			done := time.Now()
+			p.recordStats(func(s *ProcessorStats) {
+				s.Queue.record(started.Sub(queued))
+				s.Encode.record(encoded.Sub(started))
+				s.Process.record(done.Sub(started))
+			})
+		}
+	}()
+	return handle, nil
+}
+
+// recordStats mengubah statistik processor di bawah kunci
+func (p *RestaurantOrderProcessor) recordStats(fn func(s *ProcessorStats)) {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	fn(&p.stats)
+}
+
+// Stats mengembalikan salinan statistik latensi processor
+func (p *RestaurantOrderProcessor) Stats() ProcessorStats {
+	p.statsMu.Lock()
+	defer p.statsMu.Unlock()
+	return p.stats
+}
+
+// QueueCapacity jumlah pesanan yang bisa diproses bersamaan
+func (p *RestaurantOrderProcessor) QueueCapacity() int {
+	return cap(p.orders)
+}
+
+// QueueDepth jumlah pesanan yang sedang menempati slot antrian
+func (p *RestaurantOrderProcessor) QueueDepth() int {
+	return len(p.orders)
+}
+
+// Timeout batas waktu pesanan menunggu slot antrian
+func (p *RestaurantOrderProcessor) Timeout() time.Duration {
+	return p.timeout
+}
+
+// Close menghentikan penerimaan pesanan baru, menunggu semua worker selesai,
+// lalu menutup channel. Aman dipanggil lebih dari sekali.
+func (p *RestaurantOrderProcessor) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	p.wg.Wait()
+	close(p.orders)
+}
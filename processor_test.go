@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newPaidOrder pesanan berisi satu item yang sudah dikonfirmasi dan dibayar
+func newPaidOrder(t *testing.T) *Order {
+	t.Helper()
+	order := NewOrder()
+	order.AddItem("Nasi Goreng", 25000, 1)
+	if err := order.Confirm(); err != nil {
+		t.Fatal(err)
+	}
+	if err := order.MarkPaid(); err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+// newTestProcessor processor dengan kapasitas dan timeout kecil agar test cepat
+func newTestProcessor(capacity int, timeout time.Duration) *RestaurantOrderProcessor {
+	return &RestaurantOrderProcessor{
+		orders:  make(chan *Order, capacity),
+		timeout: timeout,
+	}
+}
+
+func TestProcessOrderCompletes(t *testing.T) {
+	p := NewRestaurantOrderProcessor()
+	defer p.Close()
+
+	order := newPaidOrder(t)
+	handle, err := p.ProcessOrder(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := handle.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusCompleted {
+		t.Errorf("status = %s, ingin %s", got.Status, StatusCompleted)
+	}
+	if got.Encrypted == "" {
+		t.Error("ringkasan pesanan tidak di-encode")
+	}
+}
+
+func TestProcessOrderRejectsUnpaid(t *testing.T) {
+	p := NewRestaurantOrderProcessor()
+	defer p.Close()
+
+	order := NewOrder()
+	order.AddItem("Nasi Goreng", 25000, 1)
+	if _, err := p.ProcessOrder(order); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("error = %v, ingin %v", err, ErrInvalidTransition)
+	}
+}
+
+func TestCloseWaitsForInFlightOrders(t *testing.T) {
+	p := newTestProcessor(4, time.Second)
+
+	var handles []*OrderHandle
+	for i := 0; i < 8; i++ {
+		handle, err := p.ProcessOrder(newPaidOrder(t))
+		if err != nil {
+			t.Fatal(err)
+		}
+		handles = append(handles, handle)
+	}
+	p.Close()
+
+	for i, handle := range handles {
+		select {
+		case <-handle.Done():
+		default:
+			t.Fatalf("pesanan %d belum selesai saat Close kembali", i)
+		}
+		if _, err := handle.Result(); err != nil {
+			t.Errorf("pesanan %d: %v", i, err)
+		}
+	}
+}
+
+func TestCloseWhileProcessOrderConcurrent(t *testing.T) {
+	p := newTestProcessor(2, time.Second)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handle, err := p.ProcessOrder(newPaidOrder(t))
+			if errors.Is(err, ErrProcessorClosed) {
+				return
+			}
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if _, err := handle.Result(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	p.Close()
+	wg.Wait()
+}
+
+func TestCloseTwice(t *testing.T) {
+	p := NewRestaurantOrderProcessor()
+	p.Close()
+
+	done := make(chan struct{})
+	go func() {
+		p.Close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close kedua tidak kembali")
+	}
+}
+
+func TestProcessOrderAfterClose(t *testing.T) {
+	p := NewRestaurantOrderProcessor()
+	p.Close()
+
+	if _, err := p.ProcessOrder(newPaidOrder(t)); !errors.Is(err, ErrProcessorClosed) {
+		t.Errorf("error = %v, ingin %v", err, ErrProcessorClosed)
+	}
+}
+
+func TestProcessOrderTimeout(t *testing.T) {
+	p := newTestProcessor(1, 20*time.Millisecond)
+	// Slot antrian penuh sehingga pesanan tidak pernah mendapat giliran
+	p.orders <- NewOrder()
+
+	order := newPaidOrder(t)
+	handle, err := p.ProcessOrder(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handle.Result(); !errors.Is(err, ErrProcessTimeout) {
+		t.Errorf("error = %v, ingin %v", err, ErrProcessTimeout)
+	}
+	// Pesanan yang timeout tetap dibayar dan bisa dikirim ulang
+	if order.Status != StatusPaid {
+		t.Errorf("status = %s, ingin %s", order.Status, StatusPaid)
+	}
+
+	<-p.orders
+	handle, err = p.ProcessOrder(order)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handle.Result(); err != nil {
+		t.Errorf("kirim ulang: %v", err)
+	}
+	p.Close()
+
+	// Hanya pesanan yang mendapat slot yang dicatat latensinya
+	stats := p.Stats()
+	if stats.Timeouts != 1 || stats.Queue.Count != 1 || stats.Encode.Count != 1 || stats.Process.Count != 1 {
+		t.Errorf("statistik = %+v, ingin 1 timeout dan 1 pesanan per tahap", stats)
+	}
+	if stats.Process.Max < stats.Encode.Max || stats.Process.Average() != stats.Process.Total {
+		t.Errorf("latensi proses %+v tidak konsisten dengan encode %+v", stats.Process, stats.Encode)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"sync"
+
+	"TUGAS_2MKTI/events"
+)
+
+// publicMenuCacheControl header cache untuk menu publik. Klien memvalidasi ulang
+// dengan If-None-Match setelah kedaluwarsa, sehingga biayanya hanya respons 304.
+const publicMenuCacheControl = "public, max-age=3600"
+
+// publicMenuHandler melayani GET /public/menu tanpa autentikasi. Isi respons dan
+// ETag dihitung sekali per versi menu, lalu dipakai ulang untuk setiap request.
+type publicMenuHandler struct {
+	menu MenuRepository
+
+	mu      sync.Mutex
+	version uint64
+	items   []events.MenuItemV1
+	hash    uint64
+	bodies  map[string][]byte // isi respons per content type untuk versi ini
+}
+
+// newPublicMenuHandler membuat handler menu publik dari repository menu
+func newPublicMenuHandler(menu MenuRepository) *publicMenuHandler {
+	return &publicMenuHandler{menu: menu}
+}
+
+// snapshot mengembalikan isi respons dan ETag versi menu saat ini dalam format codec
+func (h *publicMenuHandler) snapshot(codec events.Codec) ([]byte, string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if version := h.menu.Version(); h.bodies == nil || h.version != version {
+		if err := h.rebuild(version); err != nil {
+			return nil, "", err
+		}
+	}
+
+	// Setiap format punya ETag sendiri karena isinya berbeda
+	etag := fmt.Sprintf(`"%d-%x"`, h.version, h.hash)
+	if codec != events.JSON {
+		etag = fmt.Sprintf(`"%d-%x-%s"`, h.version, h.hash, strings.TrimPrefix(codec.ContentType(), "application/"))
+	}
+	body, cached := h.bodies[codec.ContentType()]
+	if !cached {
+		var err error
+		if body, err = codec.Marshal(h.items); err != nil {
+			return nil, "", err
+		}
+		h.bodies[codec.ContentType()] = body
+	}
+	return body, etag, nil
+}
+
+// rebuild menyusun ulang daftar item publik untuk versi menu baru
+func (h *publicMenuHandler) rebuild(version uint64) error {
+	entries := h.menu.List()
+	items := make([]events.MenuItemV1, len(entries))
+	for i, e := range entries {
+		items[i] = events.MenuItemV1{
+			Name:        e.Name,
+			Price:       e.Price,
+			Unit:        e.Unit,
+			Category:    e.Category,
+			Description: e.Description,
+			ImageURL:    e.ImageURL,
+			SoldOut:     e.soldOut(),
+			ValidFrom:   e.ValidFrom,
+			ValidUntil:  e.ValidUntil,
+			Tags:        e.Tags,
+		}
+		for _, w := range e.Available {
+			items[i].Available = append(items[i].Available, events.TimeWindowV1{From: w.From, Until: w.Until})
+		}
+		for _, c := range e.Bundle {
+			items[i].Bundle = append(items[i].Bundle, events.ComponentV1{Name: c.Name, Quantity: c.Quantity})
+		}
+	}
+	body, err := events.JSON.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	// Hash isi ikut masuk ETag karena nomor versi mulai ulang saat program dijalankan ulang
+	sum := fnv.New64a()
+	sum.Write(body)
+	h.version, h.items, h.hash = version, items, sum.Sum64()
+	h.bodies = map[string][]byte{events.JSON.ContentType(): body}
+	return nil
+}
+
+// ServeHTTP menangani request menu publik
+func (h *publicMenuHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "metode tidak diizinkan", http.StatusMethodNotAllowed)
+		return
+	}
+	codec, err := events.Negotiate(r.Header.Get("Accept"))
+	if err != nil {
+		http.Error(w, "format yang didukung: application/json, application/msgpack", http.StatusNotAcceptable)
+		return
+	}
+	body, etag, err := h.snapshot(codec)
+	if err != nil {
+		http.Error(w, "gagal menyiapkan menu", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Vary", "Accept")
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", publicMenuCacheControl)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", codec.ContentType())
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(body)
+}
+
+// etagMatches mengecek header If-None-Match (bisa berisi beberapa ETag atau "*")
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// runServe menjalankan server HTTP menu publik sampai program dihentikan.
+// Menu dimuat ulang otomatis jika file menu berubah.
+func runServe(args []string) error {
+	cfg, err := parseConfig(args)
+	if err != nil {
+		return err
+	}
+	menuStore, err := openMenuRepository(cfg.MenuPath)
+	if err != nil {
+		return err
+	}
+	stop := menuStore.Watch(menuReloadInterval, func(err error) {
+		if err != nil {
+			fmt.Printf("Peringatan: menu baru tidak dipakai: %v\n", err)
+			return
+		}
+		fmt.Println("Menu dimuat ulang dari file")
+	})
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/public/menu", newPublicMenuHandler(menuStore))
+	fmt.Printf("Menu publik tersedia di http://%s/public/menu\n", cfg.ServeAddr)
+	return http.ListenAndServe(cfg.ServeAddr, mux)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"TUGAS_2MKTI/events"
+)
+
+// newTestPublicMenu handler menu publik dengan dua item, salah satunya habis
+func newTestPublicMenu() (*publicMenuHandler, *InMemoryMenuRepository) {
+	soldOut := 0
+	menu := NewInMemoryMenuRepository(map[string]MenuEntry{
+		"nasi goreng": {Name: "nasi goreng", Price: 25000, Category: "makanan"},
+		"es teh":      {Name: "es teh", Price: 5000, Category: "minuman", Stock: &soldOut},
+	})
+	return newPublicMenuHandler(menu), menu
+}
+
+// getPublicMenu mengirim request ke handler dengan header tambahan
+func getPublicMenu(h http.Handler, method string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/public/menu", nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPublicMenuJSON(t *testing.T) {
+	h, _ := newTestPublicMenu()
+	rec := getPublicMenu(h, http.MethodGet, nil)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, ingin 200", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %s", got)
+	}
+	if got := rec.Header().Get("Vary"); got != "Accept" {
+		t.Errorf("Vary = %s, ingin Accept", got)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != publicMenuCacheControl {
+		t.Errorf("Cache-Control = %s", got)
+	}
+
+	var items []events.MenuItemV1
+	if err := json.Unmarshal(rec.Body.Bytes(), &items); err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Name != "es teh" || !items[0].SoldOut || items[1].SoldOut {
+		t.Errorf("isi menu = %+v", items)
+	}
+}
+
+func TestPublicMenuNegotiation(t *testing.T) {
+	h, _ := newTestPublicMenu()
+	jsonETag := getPublicMenu(h, http.MethodGet, nil).Header().Get("ETag")
+
+	tests := []struct {
+		accept      string
+		status      int
+		contentType string
+		sameETag    bool
+	}{
+		{"application/json", http.StatusOK, "application/json", true},
+		{"*/*", http.StatusOK, "application/json", true},
+		{"application/msgpack", http.StatusOK, "application/msgpack", false},
+		{"application/json;q=0.2, application/x-msgpack", http.StatusOK, "application/msgpack", false},
+		{"text/html", http.StatusNotAcceptable, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			rec := getPublicMenu(h, http.MethodGet, map[string]string{"Accept": tt.accept})
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %s, ingin %s", got, tt.contentType)
+			}
+			// Format berbeda harus punya ETag berbeda agar cache tidak tertukar
+			if etag := rec.Header().Get("ETag"); (etag == jsonETag) != tt.sameETag {
+				t.Errorf("ETag %s dibanding JSON %s", etag, jsonETag)
+			}
+		})
+	}
+}
+
+func TestPublicMenuETag(t *testing.T) {
+	h, menu := newTestPublicMenu()
+	first := getPublicMenu(h, http.MethodGet, nil)
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("ETag kosong")
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        int
+	}{
+		{"ETag sama", etag, http.StatusNotModified},
+		{"ETag lemah", "W/" + etag, http.StatusNotModified},
+		{"salah satu dari daftar", `"lama", ` + etag, http.StatusNotModified},
+		{"wildcard", "*", http.StatusNotModified},
+		{"ETag lain", `"1-0"`, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := getPublicMenu(h, http.MethodGet, map[string]string{"If-None-Match": tt.ifNoneMatch})
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, ingin %d", rec.Code, tt.want)
+			}
+			if rec.Code == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Error("respons 304 berisi body")
+			}
+		})
+	}
+
+	// Perubahan menu menghasilkan ETag baru, ETag lama tidak lagi cocok
+	if err := menu.Add(MenuEntry{Name: "ayam bakar", Price: 30000, Category: "makanan"}); err != nil {
+		t.Fatal(err)
+	}
+	rec := getPublicMenu(h, http.MethodGet, map[string]string{"If-None-Match": etag})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status setelah menu berubah = %d, ingin 200", rec.Code)
+	}
+	if rec.Header().Get("ETag") == etag {
+		t.Error("ETag tidak berubah setelah menu berubah")
+	}
+}
+
+func TestPublicMenuMethods(t *testing.T) {
+	h, _ := newTestPublicMenu()
+
+	head := getPublicMenu(h, http.MethodHead, nil)
+	if head.Code != http.StatusOK || head.Body.Len() != 0 || head.Header().Get("ETag") == "" {
+		t.Errorf("HEAD status %d, body %d byte, ETag %q", head.Code, head.Body.Len(), head.Header().Get("ETag"))
+	}
+
+	post := getPublicMenu(h, http.MethodPost, nil)
+	if post.Code != http.StatusMethodNotAllowed || post.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST status %d, Allow %q", post.Code, post.Header().Get("Allow"))
+	}
+}
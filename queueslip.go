@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// queueState isi file nomor antrian, direset setiap hari
+type queueState struct {
+	Date string `json:"date"`
+	Last int    `json:"last"`
+}
+
+// QueueNumbers membagikan nomor antrian harian yang disimpan di file
+type QueueNumbers struct {
+	mu   sync.Mutex
+	path string
+	now  func() time.Time
+}
+
+// NewQueueNumbers membuat pembagi nomor antrian dengan state di path
+func NewQueueNumbers(path string) *QueueNumbers {
+	return &QueueNumbers{path: path, now: time.Now}
+}
+
+// Next mengambil nomor antrian berikutnya untuk hari ini dan menyimpannya
+func (q *QueueNumbers) Next() (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var state queueState
+	b, err := os.ReadFile(q.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return 0, fmt.Errorf("gagal membaca nomor antrian: %w", err)
+	default:
+		if err := json.Unmarshal(b, &state); err != nil {
+			return 0, fmt.Errorf("file nomor antrian tidak valid: %w", err)
+		}
+	}
+
+	today := q.now().Format("2006-01-02")
+	if state.Date != today {
+		state = queueState{Date: today}
+	}
+	state.Last++
+
+	b, err = json.Marshal(state)
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(q.path, b, 0o644); err != nil {
+		return 0, fmt.Errorf("gagal menyimpan nomor antrian: %w", err)
+	}
+	return state.Last, nil
+}
+
+// printQueueSlip mencetak slip nomor antrian, terpisah dari struk pembayaran
+func printQueueSlip(w io.Writer, order *Order) {
+	if plainOutput {
+		fmt.Fprintf(w, "\nSlip nomor antrian. Nomor antrian: %03d. Kode pesanan: %s.\n", order.QueueNumber, order.TrackingCode)
+		return
+	}
+
+	line := strings.Repeat("=", 24)
+	fmt.Fprintf(w, "\n%s\n", line)
+	fmt.Fprintf(w, "     NOMOR ANTRIAN\n")
+	fmt.Fprintf(w, "         %03d\n", order.QueueNumber)
+	fmt.Fprintf(w, "  Kode pesanan: %s\n", order.TrackingCode)
+	fmt.Fprintf(w, "%s\n", line)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	quickItemPattern    = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)\s*x\s+([a-zA-Z\s]+)$`)
+	quickPaymentPattern = regexp.MustCompile(`^bayar\s+(\d+(\.\d+)?)$`)
+)
+
+// quickItem merepresentasikan satu item pada input cepat
+type quickItem struct {
+	Name     string
+	Quantity float64
+}
+
+// quickEntry hasil parsing input cepat, misalnya "2x nasi goreng; bayar 100000"
+type quickEntry struct {
+	Items      []quickItem
+	Payment    float64
+	HasPayment bool
+}
+
+// isQuickEntry mengecek apakah input memakai format cepat
+func isQuickEntry(input string) bool {
+	first := strings.TrimSpace(strings.SplitN(input, ";", 2)[0])
+	return strings.Contains(input, ";") || quickItemPattern.MatchString(first) || quickPaymentPattern.MatchString(first)
+}
+
+// parseQuickEntry mem-parsing input cepat dan memvalidasi nama item terhadap menu
+func parseQuickEntry(input string, menu MenuRepository) (*quickEntry, error) {
+	entry := &quickEntry{}
+	for _, part := range strings.Split(input, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if m := quickPaymentPattern.FindStringSubmatch(part); m != nil {
+			if entry.HasPayment {
+				return nil, fmt.Errorf("pembayaran hanya boleh ditulis sekali")
+			}
+			payment, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("jumlah pembayaran tidak valid: %s", m[1])
+			}
+			entry.Payment = payment
+			entry.HasPayment = true
+			continue
+		}
+
+		m := quickItemPattern.FindStringSubmatch(part)
+		if m == nil {
+			return nil, fmt.Errorf("format tidak dikenali: '%s'", part)
+		}
+		if entry.HasPayment {
+			return nil, fmt.Errorf("pembayaran harus ditulis paling akhir")
+		}
+		qty, err := parseQuantity(m[1])
+		if err != nil {
+			return nil, err
+		}
+		name := strings.Join(strings.Fields(m[2]), " ")
+		menuItem, exists := lookupMenuItem(menu, name)
+		if !exists {
+			return nil, fmt.Errorf("Menu '%s' tidak tersedia", name)
+		}
+		if err := checkAvailable(menuItem); err != nil {
+			return nil, err
+		}
+		if err := validateQuantity(menuItem, qty); err != nil {
+			return nil, err
+		}
+		if menuItem.hasRequiredModifiers() {
+			return nil, fmt.Errorf("'%s' memerlukan pilihan modifier, gunakan input biasa", name)
+		}
+		entry.Items = append(entry.Items, quickItem{Name: menuItem.Name, Quantity: qty})
+	}
+	return entry, nil
+}
+
+// checkQuickEntryStock memeriksa stok semua item input cepat sebelum ada yang
+// ditambahkan. Item yang sudah diperiksa dihitung sebagai draf tambahan, sehingga
+// item yang sama atau isi paket yang dipakai beberapa item dalam satu baris
+// tidak melebihi sisa stok pada menu live.
+func checkQuickEntryStock(orders []*Order, menu, live MenuRepository, entry *quickEntry) error {
+	pending := &Order{Status: StatusDraft}
+	orders = append(slices.Clip(orders), pending)
+	for _, item := range entry.Items {
+		menuItem, _ := menu.Get(item.Name)
+		if err := checkStock(orders, live, menuItem, item.Quantity); err != nil {
+			return err
+		}
+		pending.Items = append(pending.Items, &MenuItem{Name: menuItem.Name, Quantity: item.Quantity, Components: menuItem.Bundle})
+	}
+	return nil
+}
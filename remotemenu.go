@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// remoteMenuTimeout batas waktu satu request ke server menu pusat
+const remoteMenuTimeout = 10 * time.Second
+
+// remoteMenuMeta info cache HTTP menu pusat, disimpan di samping file cache
+// agar request setelah program dijalankan ulang tetap bersyarat
+type remoteMenuMeta struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// RemoteMenu mengambil menu dari URL pusat dan menyimpannya ke file menu lokal.
+// File lokal sekaligus menjadi cache saat jaringan tidak tersedia, sehingga
+// beberapa terminal kasir bisa berbagi satu sumber menu.
+//
+// Menu pusat menjadi pemilik definisi item: perubahan lewat admin di terminal
+// ini (harga, item baru, item dihapus) tertimpa begitu menu pusat berubah. Hanya
+// sisa stok yang tetap memakai nilai lokal, karena stok dipotong oleh pesanan
+// di terminal ini.
+type RemoteMenu struct {
+	url       string
+	cachePath string
+	client    *http.Client
+
+	mu   sync.Mutex
+	meta remoteMenuMeta
+}
+
+// NewRemoteMenu membuat sinkronisasi menu dari url ke file cachePath
+func NewRemoteMenu(url, cachePath string) *RemoteMenu {
+	r := &RemoteMenu{url: url, cachePath: cachePath, client: &http.Client{Timeout: remoteMenuTimeout}}
+	if b, err := os.ReadFile(r.metaPath()); err == nil {
+		json.Unmarshal(b, &r.meta)
+	}
+	return r
+}
+
+// metaPath path file info cache HTTP
+func (r *RemoteMenu) metaPath() string {
+	return r.cachePath + ".remote.json"
+}
+
+// fetch mengambil menu dari server. Mengembalikan nil tanpa error jika menu
+// tidak berubah sejak pengambilan terakhir (304 Not Modified).
+func (r *RemoteMenu) fetch() (map[string]MenuEntry, error) {
+	r.mu.Lock()
+	meta := r.meta
+	r.mu.Unlock()
+
+	req, err := http.NewRequest(http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("URL menu tidak valid: %w", err)
+	}
+	// Tanpa file cache, header bersyarat tidak boleh dikirim karena 304 tidak membawa isi
+	if _, err := os.Stat(r.cachePath); err == nil {
+		if meta.ETag != "" {
+			req.Header.Set("If-None-Match", meta.ETag)
+		}
+		if meta.LastModified != "" {
+			req.Header.Set("If-Modified-Since", meta.LastModified)
+		}
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gagal mengambil menu dari %s: %w", r.url, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return nil, nil
+	case http.StatusOK:
+	default:
+		return nil, fmt.Errorf("server menu %s membalas %s", r.url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca menu dari %s: %w", r.url, err)
+	}
+	menu, err := parseMenu(body)
+	if err != nil {
+		return nil, fmt.Errorf("menu dari %s: %w", r.url, err)
+	}
+
+	r.mu.Lock()
+	r.meta = remoteMenuMeta{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	meta = r.meta
+	r.mu.Unlock()
+	if b, err := json.Marshal(meta); err == nil && r.cachePath != "" {
+		os.WriteFile(r.metaPath(), b, 0o644)
+	}
+	return menu, nil
+}
+
+// Sync mengambil menu pusat dan menulisnya ke file cache. Dipanggil saat program
+// mulai, sebelum file menu dibuka. Jika gagal, file cache yang lama tetap dipakai.
+func (r *RemoteMenu) Sync() error {
+	menu, err := r.fetch()
+	if err != nil || menu == nil {
+		return err
+	}
+	if local, err := loadMenu(r.cachePath); err == nil {
+		menu = keepLocalStock(menu, local)
+	}
+	return saveMenu(r.cachePath, NewInMemoryMenuRepository(menu).List())
+}
+
+// keepLocalStock mengembalikan menu pusat dengan sisa stok dari menu lokal untuk
+// item yang stoknya dicatat di keduanya. Item baru memakai stok dari pusat.
+func keepLocalStock(remote, local map[string]MenuEntry) map[string]MenuEntry {
+	menu := make(map[string]MenuEntry, len(remote))
+	for name, entry := range remote {
+		if current, exists := local[name]; exists && current.Stock != nil && entry.Stock != nil {
+			stock := *current.Stock
+			entry.Stock = &stock
+		}
+		menu[name] = entry
+	}
+	return menu
+}
+
+// Start memperbarui repository dari menu pusat secara berkala. onRefresh dipanggil
+// setiap kali menu berubah atau gagal diambil. Interval 0 berarti menu hanya
+// diambil sekali oleh Sync. Panggil fungsi yang dikembalikan untuk berhenti.
+func (r *RemoteMenu) Start(repo *FileMenuRepository, interval time.Duration, onRefresh func(error)) (stop func()) {
+	if interval <= 0 {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				menu, err := r.fetch()
+				if err != nil {
+					onRefresh(err)
+					continue
+				}
+				if menu == nil {
+					continue
+				}
+				repo.update(func(current map[string]MenuEntry) map[string]MenuEntry {
+					return keepLocalStock(menu, current)
+				})
+				onRefresh(repo.save())
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoteSyncKeepsLocalStock(t *testing.T) {
+	remote := `[
+  {"name": "ayam bakar", "price": 32000, "category": "makanan", "stock": 10},
+  {"name": "es teh", "price": 5000, "category": "minuman", "stock": 20},
+  {"name": "kerupuk", "price": 3000, "category": "makanan"}
+]`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(remote))
+	}))
+	defer srv.Close()
+
+	stock := func(n int) *int { return &n }
+	cachePath := filepath.Join(t.TempDir(), "menu.json")
+	// Cache lokal: stok ayam bakar sudah dipotong pesanan di terminal ini
+	if err := saveMenu(cachePath, []MenuEntry{
+		{Name: "ayam bakar", Price: 30000, Category: "makanan", Stock: stock(3)},
+		{Name: "kerupuk", Price: 3000, Category: "makanan", Stock: stock(7)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewRemoteMenu(srv.URL, cachePath).Sync(); err != nil {
+		t.Fatal(err)
+	}
+	menu, err := loadMenu(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		price     float64
+		wantStock *int
+	}{
+		{"ayam bakar", 32000, stock(3)}, // harga dari pusat, stok lokal
+		{"es teh", 5000, stock(20)},     // item baru memakai stok pusat
+		{"kerupuk", 3000, nil},          // pusat tidak lagi mencatat stok
+	}
+	for _, tt := range tests {
+		entry := menu[tt.name]
+		if entry.Price != tt.price {
+			t.Errorf("%s: harga %v, ingin %v", tt.name, entry.Price, tt.price)
+		}
+		switch {
+		case tt.wantStock == nil && entry.Stock != nil:
+			t.Errorf("%s: stok %d, ingin tidak dilacak", tt.name, *entry.Stock)
+		case tt.wantStock != nil && (entry.Stock == nil || *entry.Stock != *tt.wantStock):
+			t.Errorf("%s: stok %v, ingin %d", tt.name, entry.Stock, *tt.wantStock)
+		}
+	}
+}
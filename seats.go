@@ -0,0 +1,66 @@
+package main
+
+import "sort"
+
+// SeatBill rincian tagihan untuk satu nomor kursi
+type SeatBill struct {
+	Seat   int
+	Items  []*MenuItem
+	Shared []*MenuItem // item bersama (Seat 0) yang ikut ditanggung kursi ini
+	// Subtotal jumlah harga item sebelum pajak dan potongan, termasuk bagian item bersama
+	Subtotal float64
+	Amount   float64 // bagian dari total pesanan, termasuk pajak dan potongan
+}
+
+// SeatBills mengelompokkan item pesanan berdasarkan nomor kursi, diurutkan dari kursi terkecil.
+// Item tanpa kursi (Seat 0) dibagi rata ke semua kursi; hanya jika tidak ada item
+// berkursi, item tersebut menjadi satu tagihan bersama. Total pesanan dibagi dengan
+// cara yang sama seperti bagi tagihan per item, termasuk pajak per kelas.
+func (o *Order) SeatBills() []SeatBill {
+	bills := make(map[int]*SeatBill)
+	var shared []*MenuItem
+	for _, item := range o.Items {
+		if item.Seat == 0 {
+			shared = append(shared, item)
+			continue
+		}
+		bill, exists := bills[item.Seat]
+		if !exists {
+			bill = &SeatBill{Seat: item.Seat}
+			bills[item.Seat] = bill
+		}
+		bill.Items = append(bill.Items, item)
+	}
+	if len(bills) == 0 && len(shared) > 0 {
+		bills[0] = &SeatBill{Items: shared}
+		shared = nil
+	}
+
+	result := make([]SeatBill, 0, len(bills))
+	for _, bill := range bills {
+		bill.Shared = shared
+		result = append(result, *bill)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Seat < result[j].Seat })
+	if len(result) == 0 {
+		return result
+	}
+
+	sharePerSeat := 1 / float64(len(result))
+	payers := make([][]billShare, len(result))
+	for i := range result {
+		bill := &result[i]
+		for _, item := range bill.Items {
+			payers[i] = append(payers[i], billShare{Item: item, Share: 1})
+			bill.Subtotal += item.LineTotal()
+		}
+		for _, item := range bill.Shared {
+			payers[i] = append(payers[i], billShare{Item: item, Share: sharePerSeat})
+			bill.Subtotal += item.LineTotal() * sharePerSeat
+		}
+	}
+	for i, amount := range splitAmounts(o.Total, o.payerWeights(payers)) {
+		result[i].Amount = amount
+	}
+	return result
+}
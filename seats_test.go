@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+func TestSeatBillsDistributeTotal(t *testing.T) {
+	order := NewOrder()
+	order.AddItemForSeat("Nasi Goreng", 25000, 1, 1)
+	order.AddItemForSeat("Ayam Bakar", 30000, 1, 2)
+	order.AddItemForSeat("Es Teh", 5000, 3, 0)
+	order.AddItemForSeat("Kerupuk", 5000, 1, 1)
+	// Total setelah pajak dan potongan, misalnya subtotal 75.000 ditambah PPN 11%
+	order.Total = 83250
+
+	// Es teh tanpa kursi dibagi rata ke kursi 1 dan 2
+	bills := order.SeatBills()
+	want := []struct {
+		seat     int
+		subtotal float64
+		amount   float64
+	}{
+		{1, 37500, 41625},
+		{2, 37500, 41625},
+	}
+	if len(bills) != len(want) {
+		t.Fatalf("jumlah tagihan = %d, ingin %d", len(bills), len(want))
+	}
+	sum := 0.0
+	for i, w := range want {
+		bill := bills[i]
+		if len(bill.Shared) != 1 || bill.Shared[0].Name != "Es Teh" {
+			t.Errorf("kursi %d: item bersama %v", bill.Seat, bill.Shared)
+		}
+		if bill.Seat != w.seat || bill.Subtotal != w.subtotal || bill.Amount != w.amount {
+			t.Errorf("kursi %d: subtotal %v tagihan %v, ingin kursi %d subtotal %v tagihan %v",
+				bill.Seat, bill.Subtotal, bill.Amount, w.seat, w.subtotal, w.amount)
+		}
+		sum += bill.Amount
+	}
+	if sum != order.Total {
+		t.Errorf("jumlah tagihan kursi %v, ingin total pesanan %v", sum, order.Total)
+	}
+}
+
+func TestSeatBillsEmptyOrder(t *testing.T) {
+	if bills := NewOrder().SeatBills(); len(bills) != 0 {
+		t.Errorf("tagihan = %v, ingin kosong", bills)
+	}
+}
+
+func TestSeatBillsTaxPerSeat(t *testing.T) {
+	withTaxRates(t, map[string]float64{defaultTaxClass: 10, "bebas": 0})
+
+	order := NewOrder()
+	order.AddItemForSeat("Nasi Goreng", 25000, 2, 1)
+	order.AddItemForSeat("Air Mineral", 10000, 1, 2)
+	order.Items[1].TaxClass = "bebas"
+	order.calculateTotal()
+
+	// Kursi 2 hanya memesan item bebas pajak
+	bills := order.SeatBills()
+	if len(bills) != 2 || bills[0].Amount != 55000 || bills[1].Amount != 10000 {
+		t.Errorf("tagihan kursi = %+v, ingin 55000 dan 10000", bills)
+	}
+}
+
+func TestSeatBillsOnlyShared(t *testing.T) {
+	order := NewOrder()
+	order.AddItem("Nasi Goreng", 25000, 2)
+	order.Total = 50000
+
+	bills := order.SeatBills()
+	if len(bills) != 1 || bills[0].Seat != 0 || len(bills[0].Items) != 1 || bills[0].Amount != 50000 {
+		t.Errorf("tagihan = %+v, ingin satu tagihan bersama", bills)
+	}
+}
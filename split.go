@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SplitBill satu bagian tagihan untuk satu pembayar
+type SplitBill struct {
+	Payer   int
+	Items   []*MenuItem // kosong jika tagihan dibagi rata
+	Amount  float64
+	Payment float64
+	Change  float64
+}
+
+// splitAmounts membagi total ke beberapa bagian sesuai bobotnya, dibulatkan ke
+// digit desimal locale. Selisih pembulatan ditanggung bagian terakhir agar jumlahnya
+// tetap sama dengan total.
+func splitAmounts(total float64, weights []float64) []float64 {
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	scale := math.Pow10(currentLocale.Decimals)
+	amounts := make([]float64, len(weights))
+	rest := total
+	for i, w := range weights[:len(weights)-1] {
+		if sum > 0 {
+			amounts[i] = math.Floor(total*w/sum*scale) / scale
+		}
+		rest -= amounts[i]
+	}
+	amounts[len(amounts)-1] = currentLocale.Round(rest)
+	return amounts
+}
+
+// billShare bagian satu baris pesanan yang ditanggung pembayar; Share 1 berarti seluruh baris
+type billShare struct {
+	Item  *MenuItem
+	Share float64
+}
+
+// payerWeights bobot setiap pembayar untuk splitAmounts, dengan jumlah sama dengan
+// total pesanan. Total sebelum pajak (setelah potongan) dibagi sebanding total baris,
+// sedangkan pajak setiap kelas hanya dibagi ke pembayar yang punya baris di kelas
+// tersebut, sehingga pembayar item bebas pajak tidak ikut menanggung pajak item lain.
+func (o *Order) payerWeights(payers [][]billShare) []float64 {
+	lineTotal := 0.0
+	classBase := make(map[string]float64)
+	for _, item := range o.Items {
+		lineTotal += item.LineTotal()
+		classBase[item.taxClass()] += item.LineTotal()
+	}
+	preTax := o.Total
+	classTax := make(map[string]float64)
+	for _, tax := range o.Taxes {
+		preTax -= tax.Amount
+		classTax[tax.Class] = tax.Amount
+	}
+
+	weights := make([]float64, len(payers))
+	for i, shares := range payers {
+		for _, s := range shares {
+			line := s.Item.LineTotal() * s.Share
+			if lineTotal > 0 {
+				weights[i] += preTax * line / lineTotal
+			}
+			if base := classBase[s.Item.taxClass()]; base > 0 {
+				weights[i] += classTax[s.Item.taxClass()] * line / base
+			}
+		}
+	}
+	return weights
+}
+
+// SplitEvenly membagi total pesanan rata untuk n pembayar
+func (o *Order) SplitEvenly(n int) ([]SplitBill, error) {
+	if n < 2 {
+		return nil, fmt.Errorf("tagihan harus dibagi untuk minimal 2 pembayar")
+	}
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	bills := make([]SplitBill, n)
+	for i, amount := range splitAmounts(o.Total, weights) {
+		bills[i] = SplitBill{Payer: i + 1, Amount: amount}
+	}
+	return bills, nil
+}
+
+// SplitByItems membagi tagihan sesuai item yang dipilih tiap pembayar. groups berisi
+// nomor item (dihitung dari 0) per pembayar dan setiap item harus dibagi tepat sekali.
+// Potongan dibagi sebanding subtotal item masing-masing, sedangkan pajak dihitung
+// dari item masing-masing pembayar per kelas pajak.
+func (o *Order) SplitByItems(groups [][]int) ([]SplitBill, error) {
+	if len(groups) < 2 {
+		return nil, fmt.Errorf("tagihan harus dibagi untuk minimal 2 pembayar")
+	}
+	assigned := make(map[int]int)
+	bills := make([]SplitBill, len(groups))
+	shares := make([][]billShare, len(groups))
+	for payer, group := range groups {
+		if len(group) == 0 {
+			return nil, fmt.Errorf("pembayar %d belum punya item", payer+1)
+		}
+		bills[payer].Payer = payer + 1
+		for _, index := range group {
+			if index < 0 || index >= len(o.Items) {
+				return nil, fmt.Errorf("item nomor %d tidak ada di pesanan", index+1)
+			}
+			if other, exists := assigned[index]; exists {
+				return nil, fmt.Errorf("item nomor %d sudah dibagi ke pembayar %d", index+1, other+1)
+			}
+			assigned[index] = payer
+			bills[payer].Items = append(bills[payer].Items, o.Items[index])
+			shares[payer] = append(shares[payer], billShare{Item: o.Items[index], Share: 1})
+		}
+	}
+	for i := range o.Items {
+		if _, exists := assigned[i]; !exists {
+			return nil, fmt.Errorf("item nomor %d belum dibagi ke pembayar", i+1)
+		}
+	}
+
+	for i, amount := range splitAmounts(o.Total, o.payerWeights(shares)) {
+		bills[i].Amount = amount
+	}
+	return bills, nil
+}
+
+// Pay mencatat pembayaran satu bagian tagihan
+func (b *SplitBill) Pay(payment float64) error {
+	if payment < b.Amount {
+		return fmt.Errorf("pembayaran pembayar %d kurang %s", b.Payer, currentLocale.FormatMoney(b.Amount-payment))
+	}
+	b.Payment = payment
+	b.Change = payment - b.Amount
+	return nil
+}
+
+// parseSplitCommand mem-parsing "bagi <jumlah pembayar>" untuk bagi rata, atau
+// "bagi 1,2/3" untuk membagi per item: nomor item dipisah koma, pembayar dipisah "/"
+func parseSplitCommand(input string, order *Order) ([]SplitBill, error) {
+	arg := strings.TrimSpace(strings.TrimPrefix(input, "bagi"))
+	if arg == "" {
+		return nil, fmt.Errorf("format: bagi <jumlah pembayar> atau bagi 1,2/3")
+	}
+	if !strings.ContainsAny(arg, ",/") {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("jumlah pembayar tidak valid: %s", arg)
+		}
+		return order.SplitEvenly(n)
+	}
+
+	var groups [][]int
+	for _, part := range strings.Split(arg, "/") {
+		var group []int
+		for _, field := range strings.Split(part, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			number, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("nomor item tidak valid: %s", field)
+			}
+			group = append(group, number-1)
+		}
+		groups = append(groups, group)
+	}
+	return order.SplitByItems(groups)
+}
+
+// promptSplitPayments menanyakan pembayaran setiap pembayar sampai cukup
+func promptSplitPayments(reader *bufio.Reader, bills []SplitBill) error {
+	for i := range bills {
+		bill := &bills[i]
+		for {
+			fmt.Printf("Pembayar %d, tagihan %s. Masukkan jumlah uang: ", bill.Payer, currentLocale.FormatMoney(bill.Amount))
+			answer, err := readLine(reader)
+			if err != nil {
+				return err
+			}
+			payment, err := strconv.ParseFloat(answer, 64)
+			if err != nil {
+				fmt.Println("Error: Jumlah pembayaran tidak valid")
+				continue
+			}
+			if err := bill.Pay(payment); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				continue
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitAmounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		locale  string
+		total   float64
+		weights []float64
+		want    []float64
+	}{
+		{"bagi rata tanpa sisa", "id-ID", 90000, []float64{1, 1, 1}, []float64{30000, 30000, 30000}},
+		{"sisa pembulatan ke bagian terakhir", "id-ID", 100000, []float64{1, 1, 1}, []float64{33333, 33333, 33334}},
+		{"sisa pembulatan dua desimal", "en-US", 100, []float64{1, 1, 1}, []float64{33.33, 33.33, 33.34}},
+		{"sebanding bobot", "id-ID", 60000, []float64{10000, 20000}, []float64{20000, 40000}},
+		{"bobot nol tidak menanggung tagihan", "id-ID", 50000, []float64{0, 25000}, []float64{0, 50000}},
+		{"semua bobot nol", "id-ID", 50000, []float64{0, 0}, []float64{0, 50000}},
+	}
+	defer func(l Locale) { currentLocale = l }(currentLocale)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currentLocale = locales[tt.locale]
+			got := splitAmounts(tt.total, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitAmounts(%v, %v) = %v, ingin %v", tt.total, tt.weights, got, tt.want)
+			}
+			sum := 0.0
+			for _, amount := range got {
+				sum += amount
+			}
+			if sum != tt.total {
+				t.Errorf("jumlah bagian %v, ingin %v", sum, tt.total)
+			}
+		})
+	}
+}
+
+func TestSplitByItems(t *testing.T) {
+	newSplitOrder := func() *Order {
+		order := NewOrder()
+		order.AddItem("Nasi Goreng", 25000, 1)
+		order.AddItem("Ayam Bakar", 30000, 1)
+		order.AddItem("Air Putih", 0, 1)
+		order.Total = 55000
+		return order
+	}
+
+	tests := []struct {
+		name    string
+		groups  [][]int
+		want    []float64
+		wantErr string
+	}{
+		{name: "per item", groups: [][]int{{0, 2}, {1}}, want: []float64{25000, 30000}},
+		{name: "pembayar dengan item gratis", groups: [][]int{{2}, {0, 1}}, want: []float64{0, 55000}},
+		{name: "item belum dibagi", groups: [][]int{{0}, {1}}, wantErr: "item nomor 3 belum dibagi"},
+		{name: "item dibagi dua kali", groups: [][]int{{0, 1}, {1, 2}}, wantErr: "item nomor 2 sudah dibagi ke pembayar 1"},
+		{name: "pembayar tanpa item", groups: [][]int{{0, 1, 2}, {}}, wantErr: "pembayar 2 belum punya item"},
+		{name: "nomor item di luar pesanan", groups: [][]int{{0, 1, 2}, {5}}, wantErr: "item nomor 6 tidak ada"},
+		{name: "satu pembayar", groups: [][]int{{0, 1, 2}}, wantErr: "minimal 2 pembayar"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bills, err := newSplitOrder().SplitByItems(tt.groups)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, ingin mengandung %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error tidak terduga: %v", err)
+			}
+			got := make([]float64, len(bills))
+			for i, bill := range bills {
+				got[i] = bill.Amount
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tagihan = %v, ingin %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSplitCommand(t *testing.T) {
+	order := NewOrder()
+	order.AddItem("Nasi Goreng", 25000, 1)
+	order.AddItem("Ayam Bakar", 30000, 1)
+	order.Total = 55000
+
+	tests := []struct {
+		input   string
+		payers  int
+		wantErr bool
+	}{
+		{"bagi 2", 2, false},
+		{"bagi 1/2", 2, false},
+		{"bagi 1, 2/", 0, true},
+		{"bagi", 0, true},
+		{"bagi x", 0, true},
+		{"bagi 1", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			bills, err := parseSplitCommand(tt.input, order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, ingin error %v", err, tt.wantErr)
+			}
+			if len(bills) != tt.payers {
+				t.Errorf("jumlah pembayar = %d, ingin %d", len(bills), tt.payers)
+			}
+		})
+	}
+}
+
+func TestSplitByItemsTaxPerPayer(t *testing.T) {
+	withTaxRates(t, map[string]float64{defaultTaxClass: 10, "bebas": 0})
+	withRole(t, "kasir")
+
+	order := NewOrder()
+	order.AddItem("Nasi Goreng", 25000, 2)
+	order.AddItem("Air Mineral", 10000, 1)
+	order.Items[1].TaxClass = "bebas"
+	order.calculateTotal()
+
+	tests := []struct {
+		name     string
+		discount float64
+		want     []float64
+	}{
+		// Pembayar air mineral tidak ikut menanggung pajak nasi goreng
+		{"tanpa diskon", 0, []float64{55000, 10000}},
+		// Diskon 6.000 dibagi 5:1, pajak dihitung dari dasar setelah diskon
+		{"dengan diskon", 6000, []float64{49500, 9000}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.discount > 0 {
+				if err := order.ApplyDiscount(DiscountFixed, tt.discount); err != nil {
+					t.Fatal(err)
+				}
+			}
+			bills, err := order.SplitByItems([][]int{{0}, {1}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []float64{bills[0].Amount, bills[1].Amount}
+			if !reflect.DeepEqual(got, tt.want) || got[0]+got[1] != order.Total {
+				t.Errorf("tagihan = %v, ingin %v (total %v)", got, tt.want, order.Total)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// menuKey mengubah nama item pada pesanan menjadi nama item di menu
+func menuKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// soldOut mengecek apakah stok item sudah habis
+func (e MenuEntry) soldOut() bool {
+	return e.Stock != nil && *e.Stock <= 0
+}
+
+// orderedQuantity jumlah item yang sudah ada di pesanan, termasuk sebagai isi paket
+func orderedQuantity(order *Order, name string) float64 {
+	total := 0.0
+	for _, item := range order.Items {
+		if menuKey(item.Name) == name {
+			total += item.Quantity
+		}
+		for _, c := range item.Components {
+			if c.Name == name {
+				total += item.Quantity * float64(c.Quantity)
+			}
+		}
+	}
+	return total
+}
+
+// reservedQuantity jumlah item yang sudah dipesan di semua pesanan terbuka.
+// Pesanan yang sudah dikonfirmasi dilewati karena stoknya sudah dipotong commitStock.
+func reservedQuantity(orders []*Order, name string) float64 {
+	total := 0.0
+	for _, order := range orders {
+		if order.Status != StatusDraft {
+			continue
+		}
+		total += orderedQuantity(order, name)
+	}
+	return total
+}
+
+// checkStock mengembalikan error jika jumlah yang diminta melebihi sisa stok
+// pada menu live, dengan memperhitungkan jumlah yang sudah ada di semua
+// pesanan terbuka. Untuk paket, stok setiap item isinya ikut diperiksa.
+func checkStock(orders []*Order, menu MenuRepository, entry MenuEntry, quantity float64) error {
+	// entry bisa berasal dari snapshot pesanan; stok selalu dibaca dari menu live
+	if live, exists := menu.Get(entry.Name); exists {
+		entry = live
+	}
+	for _, c := range entry.Bundle {
+		component, exists := menu.Get(c.Name)
+		if !exists {
+			return fmt.Errorf("isi paket '%s' tidak tersedia", c.Name)
+		}
+		if err := checkStock(orders, menu, component, quantity*float64(c.Quantity)); err != nil {
+			return fmt.Errorf("paket '%s': %w", entry.Name, err)
+		}
+	}
+	if entry.Stock == nil {
+		return nil
+	}
+	remaining := float64(*entry.Stock) - reservedQuantity(orders, entry.Name)
+	if remaining <= 0 {
+		return fmt.Errorf("Menu '%s' sudah habis", entry.Name)
+	}
+	if quantity > remaining {
+		return fmt.Errorf("stok '%s' tersisa %s", entry.Name, formatQuantity(remaining))
+	}
+	return nil
+}
+
+// commitStock mengurangi stok menu sesuai pesanan yang sudah dikonfirmasi dalam
+// satu langkah: jika stok salah satu item tidak cukup, tidak ada stok yang dipotong.
+// Mengembalikan item yang stoknya baru saja melewati batas low_stock.
+func commitStock(order *Order, menu MenuRepository) ([]MenuEntry, error) {
+	delta := make(map[string]int)
+	for name, qty := range stockNeeded(order) {
+		delta[name] = -qty
+	}
+	before, err := menu.AdjustStock(delta)
+	if err != nil {
+		return nil, err
+	}
+
+	var low []MenuEntry
+	for name, stock := range before {
+		entry, _ := menu.Get(name)
+		if crossedLowStock(entry, stock, stock+delta[name]) {
+			low = append(low, entry)
+		}
+	}
+	return low, nil
+}
+
+// stockNeeded jumlah stok yang dipakai pesanan per item menu, termasuk isi paket.
+// Item dengan stok selalu dipesan dalam jumlah bulat (lihat validateMenuEntry).
+func stockNeeded(order *Order) map[string]int {
+	needed := make(map[string]int)
+	for _, item := range order.Items {
+		needed[menuKey(item.Name)] += int(item.Quantity)
+		for _, c := range item.Components {
+			needed[c.Name] += int(item.Quantity) * c.Quantity
+		}
+	}
+	return needed
+}
+
+// releaseStock mengembalikan stok yang sudah dipotong commitStock, misalnya saat
+// pesanan yang sudah dikonfirmasi dibatalkan
+func releaseStock(order *Order, menu MenuRepository) error {
+	_, err := menu.AdjustStock(stockNeeded(order))
+	return err
+}
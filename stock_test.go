@@ -0,0 +1,141 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckStockAcrossOpenOrders(t *testing.T) {
+	stock := func(n int) *int { return &n }
+	live := NewInMemoryMenuRepository(map[string]MenuEntry{
+		"ayam bakar": {Name: "ayam bakar", Price: 30000, Category: "makanan", Stock: stock(5)},
+		"es teh":     {Name: "es teh", Price: 5000, Category: "minuman"},
+	})
+	// Snapshot tiket masih mencatat stok lama
+	snapshot := live.Snapshot()
+	entry, _ := snapshot.Get("ayam bakar")
+	if err := live.Add(MenuEntry{Name: "ayam bakar", Price: 30000, Category: "makanan", Stock: stock(3)}); err != nil {
+		t.Fatal(err)
+	}
+
+	table1, table2 := NewOrder(), NewOrder()
+	table1.AddItem("Ayam Bakar", 30000, 2)
+	confirmed := NewOrder()
+	confirmed.AddItem("Ayam Bakar", 30000, 4)
+	confirmed.Status = StatusConfirmed // stoknya sudah dipotong, tidak dihitung lagi
+	open := []*Order{table1, table2, confirmed}
+
+	tests := []struct {
+		name     string
+		quantity float64
+		wantErr  string
+	}{
+		{"sisa stok live", 1, ""},
+		{"melebihi sisa stok live", 2, "tersisa 1"},
+		{"masih sesuai snapshot lama", 3, "tersisa 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStock(open, live, entry, tt.quantity)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, ingin mengandung %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	table2.AddItem("Ayam Bakar", 30000, 1)
+	if err := checkStock(open, live, entry, 1); err == nil || !strings.Contains(err.Error(), "habis") {
+		t.Errorf("error = %v, ingin stok habis", err)
+	}
+	teh, _ := live.Get("es teh")
+	if err := checkStock(open, live, teh, 100); err != nil {
+		t.Errorf("item tanpa stok ditolak: %v", err)
+	}
+}
+
+func TestCheckQuickEntryStockCountsBundles(t *testing.T) {
+	stock := func(n int) *int { return &n }
+	live := NewInMemoryMenuRepository(map[string]MenuEntry{
+		"ayam bakar": {Name: "ayam bakar", Price: 30000, Category: "makanan", Stock: stock(3)},
+		"es teh":     {Name: "es teh", Price: 5000, Category: "minuman"},
+		"paket ayam": {Name: "paket ayam", Price: 32000, Category: "paket", Bundle: []BundleComponent{
+			{Name: "ayam bakar", Quantity: 1}, {Name: "es teh", Quantity: 1},
+		}},
+	})
+
+	tests := []struct {
+		input   string
+		wantErr string
+	}{
+		{"2x ayam bakar; 1x paket ayam", ""},
+		{"2x paket ayam; 2x ayam bakar", "tersisa 1"},
+		{"1x ayam bakar; 1x paket ayam; 2x ayam bakar", "tersisa 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			entry, err := parseQuickEntry(tt.input, live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = checkQuickEntryStock(nil, live, live, entry)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, ingin mengandung %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommitStockAllOrNothing(t *testing.T) {
+	stock := func(n int) *int { return &n }
+	menu := NewInMemoryMenuRepository(map[string]MenuEntry{
+		"ayam bakar": {Name: "ayam bakar", Price: 30000, Category: "makanan", Stock: stock(5), LowStock: stock(3)},
+		"es teh":     {Name: "es teh", Price: 5000, Category: "minuman", Stock: stock(1)},
+	})
+	remaining := func(name string) int {
+		entry, _ := menu.Get(name)
+		return *entry.Stock
+	}
+
+	short := NewOrder()
+	short.AddItem("Ayam Bakar", 30000, 2)
+	short.AddItem("Es Teh", 5000, 2)
+	if _, err := commitStock(short, menu); err == nil || !strings.Contains(err.Error(), "tersisa 1") {
+		t.Fatalf("error = %v, ingin stok es teh kurang", err)
+	}
+	if remaining("ayam bakar") != 5 || remaining("es teh") != 1 {
+		t.Errorf("stok berubah walaupun ditolak: ayam %d, es teh %d", remaining("ayam bakar"), remaining("es teh"))
+	}
+
+	order := NewOrder()
+	order.AddItem("Ayam Bakar", 30000, 2)
+	order.AddItem("Es Teh", 5000, 1)
+	low, err := commitStock(order, menu)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remaining("ayam bakar") != 3 || remaining("es teh") != 0 {
+		t.Errorf("stok ayam %d, es teh %d, ingin 3 dan 0", remaining("ayam bakar"), remaining("es teh"))
+	}
+	if len(low) != 1 || low[0].Name != "ayam bakar" {
+		t.Errorf("stok menipis = %+v, ingin ayam bakar", low)
+	}
+
+	if err := releaseStock(order, menu); err != nil {
+		t.Fatal(err)
+	}
+	if remaining("ayam bakar") != 5 || remaining("es teh") != 1 {
+		t.Errorf("stok setelah dikembalikan: ayam %d, es teh %d", remaining("ayam bakar"), remaining("es teh"))
+	}
+}
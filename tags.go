@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// hasTag mengecek apakah item menu memiliki tag tertentu
+func (e MenuEntry) hasTag(tag string) bool {
+	for _, t := range e.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// filterByTag mengembalikan item yang memiliki tag, atau yang tidak memilikinya jika without true
+func filterByTag(entries []MenuEntry, tag string, without bool) []MenuEntry {
+	var result []MenuEntry
+	for _, entry := range entries {
+		if entry.hasTag(tag) != without {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// runTagCommand menjalankan perintah "tag": tanpa argumen menampilkan semua tag,
+// "tag <nama>" menampilkan item dengan tag tersebut (misalnya "tag vegetarian"),
+// dan "tag tanpa <nama>" menampilkan item tanpa tag tersebut (misalnya "tag tanpa kacang")
+func runTagCommand(w io.Writer, input string, entries []MenuEntry, pricing *compiledPricing) error {
+	arg := strings.TrimSpace(strings.TrimPrefix(input, "tag"))
+	if arg == "" {
+		counts := make(map[string]int)
+		for _, entry := range entries {
+			for _, t := range entry.Tags {
+				counts[t]++
+			}
+		}
+		if len(counts) == 0 {
+			fmt.Fprintln(w, "Belum ada item menu yang diberi tag")
+			return nil
+		}
+		tags := make([]string, 0, len(counts))
+		for t := range counts {
+			tags = append(tags, t)
+		}
+		sort.Strings(tags)
+		fmt.Fprintln(w, "\nTag:")
+		for _, t := range tags {
+			fmt.Fprintf(w, "- %s (%d item)\n", t, counts[t])
+		}
+		return nil
+	}
+
+	without := false
+	if rest, ok := strings.CutPrefix(arg, "tanpa "); ok {
+		without, arg = true, strings.TrimSpace(rest)
+	}
+	matches := filterByTag(entries, arg, without)
+	if len(matches) == 0 {
+		if without {
+			return fmt.Errorf("semua item memiliki tag '%s'", arg)
+		}
+		return fmt.Errorf("tidak ada item dengan tag '%s'", arg)
+	}
+	printMenu(w, matches, pricing, "")
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// defaultTaxClass kelas pajak untuk item menu tanpa tax_class
+const defaultTaxClass = "standar"
+
+// TaxLine total pajak satu kelas pada pesanan
+type TaxLine struct {
+	Class  string
+	Rate   float64 // persen
+	Base   float64
+	Amount float64
+}
+
+// taxRates tarif pajak (persen) per kelas, dibaca dari file -tax-rates,
+// misalnya {"standar": 11, "minuman-botol": 0}. Kosong berarti pajak tidak dihitung.
+var taxRates map[string]float64
+
+// loadTaxRates membaca tarif pajak per kelas dari file JSON
+func loadTaxRates(path string) (map[string]float64, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca tarif pajak: %w", err)
+	}
+	var rates map[string]float64
+	if err := json.Unmarshal(b, &rates); err != nil {
+		return nil, fmt.Errorf("format tarif pajak tidak valid: %w", err)
+	}
+	normalized := make(map[string]float64, len(rates))
+	for class, rate := range rates {
+		if rate < 0 || rate > 100 {
+			return nil, fmt.Errorf("tarif pajak '%s' harus antara 0 dan 100", class)
+		}
+		normalized[strings.ToLower(strings.TrimSpace(class))] = rate
+	}
+	return normalized, nil
+}
+
+// validateTaxClasses memastikan setiap kelas pajak di menu punya tarif
+func validateTaxClasses(entries []MenuEntry, rates map[string]float64) error {
+	if rates == nil {
+		return nil
+	}
+	for _, e := range entries {
+		if _, exists := rates[e.taxClass()]; !exists {
+			return fmt.Errorf("kelas pajak '%s' pada '%s' tidak punya tarif", e.taxClass(), e.Name)
+		}
+	}
+	return nil
+}
+
+// taxClass kelas pajak item menu, atau kelas standar jika tidak diisi
+func (e MenuEntry) taxClass() string {
+	if e.TaxClass == "" {
+		return defaultTaxClass
+	}
+	return e.TaxClass
+}
+
+// taxClass kelas pajak baris pesanan, atau kelas standar jika tidak diisi
+func (m *MenuItem) taxClass() string {
+	if m.TaxClass == "" {
+		return defaultTaxClass
+	}
+	return m.TaxClass
+}
+
+// computeTaxes menghitung pajak per kelas dari total baris pesanan, diurutkan berdasarkan kelas.
+// Pajak setiap kelas dibulatkan ke digit desimal locale.
+// Potongan pesanan mengurangi dasar pajak setiap kelas secara sebanding terhadap
+// subtotal pricing engine, subtotal yang sama dengan dasar perhitungan potongan.
+func computeTaxes(order *Order, rates map[string]float64, subtotal float64) []TaxLine {
+	if rates == nil {
+		return nil
+	}
+	bases := make(map[string]float64)
+	for _, item := range order.Items {
+		bases[item.taxClass()] += item.LineTotal()
+	}
+	if order.Discount != nil && subtotal > 0 {
+		factor := 1 - order.Discount.Amount/subtotal
+		for class := range bases {
+			bases[class] *= factor
+		}
+	}
+
+	lines := make([]TaxLine, 0, len(bases))
+	for class, base := range bases {
+		rate := rates[class]
+		lines = append(lines, TaxLine{
+			Class:  class,
+			Rate:   rate,
+			Base:   base,
+			Amount: currentLocale.Round(base * rate / 100),
+		})
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i].Class < lines[j].Class })
+	return lines
+}
+
+// describeTax memformat label pajak, misalnya "PPN standar (11%)"
+func describeTax(line TaxLine) string {
+	return fmt.Sprintf("%s %s (%g%%)", currentLocale.TaxName, line.Class, line.Rate)
+}
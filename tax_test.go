@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestComputeTaxesRoundsToLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		price  float64
+		want   float64
+	}{
+		{"id-ID", 12345, 1358}, // 1357,95 dibulatkan ke rupiah penuh
+		{"en-US", 12.35, 1.36}, // 1,3585 dibulatkan ke sen
+	}
+	defer func(l Locale) { currentLocale = l }(currentLocale)
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			currentLocale = locales[tt.locale]
+			order := NewOrder()
+			order.AddItem("Nasi Goreng", tt.price, 1)
+			lines := computeTaxes(order, map[string]float64{defaultTaxClass: 11}, order.Items[0].LineTotal())
+			if len(lines) != 1 || lines[0].Amount != tt.want {
+				t.Errorf("pajak = %+v, ingin %v", lines, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// trackingAlphabet tanpa karakter yang mudah tertukar saat dibacakan (0/O, 1/I/L)
+const trackingAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// TrackingCodeGenerator membuat kode pesanan pendek seperti "AR7-29K".
+// Satu instance dipakai per toko, dan kode dijamin unik per hari selama proses berjalan.
+// Kode pesanan yang tersimpan di luar proses, misalnya pesanan ditahan, didaftarkan
+// lewat Reserve agar tidak diberikan lagi setelah program dibuka ulang.
+type TrackingCodeGenerator struct {
+	mu       sync.Mutex
+	day      string
+	issued   map[string]bool
+	reserved map[string]bool
+}
+
+// NewTrackingCodeGenerator membuat generator kode baru
+func NewTrackingCodeGenerator() *TrackingCodeGenerator {
+	return &TrackingCodeGenerator{
+		issued:   make(map[string]bool),
+		reserved: make(map[string]bool),
+	}
+}
+
+// Reserve menandai kode yang masih dipakai pesanan tersimpan. Berbeda dengan kode
+// yang diberikan Next, kode ini tetap dilewati walaupun hari sudah berganti.
+func (g *TrackingCodeGenerator) Reserve(codes ...string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, code := range codes {
+		g.reserved[strings.ToUpper(code)] = true
+	}
+}
+
+// Next menghasilkan kode baru yang belum pernah dipakai hari ini
+func (g *TrackingCodeGenerator) Next() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	day := clock.Now().Format("2006-01-02")
+	if day != g.day {
+		g.day = day
+		g.issued = make(map[string]bool)
+	}
+
+	for {
+		code := randomCode(3) + "-" + randomCode(3)
+		if !g.issued[code] && !g.reserved[code] {
+			g.issued[code] = true
+			return code
+		}
+	}
+}
+
+// randomCode menghasilkan n karakter acak dari trackingAlphabet
+func randomCode(n int) string {
+	var b strings.Builder
+	max := big.NewInt(int64(len(trackingAlphabet)))
+	for i := 0; i < n; i++ {
+		idx, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(err)
+		}
+		b.WriteByte(trackingAlphabet[idx.Int64()])
+	}
+	return b.String()
+}
+
+// trackingCodes adalah generator kode untuk toko yang sedang berjalan
+var trackingCodes = NewTrackingCodeGenerator()
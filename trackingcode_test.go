@@ -0,0 +1,54 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+	"time"
+)
+
+// fixedClock jam yang bisa diatur dari test
+type fixedClock struct{ t time.Time }
+
+func (c *fixedClock) Now() time.Time { return c.t }
+
+// withClock memasang clock untuk satu test lalu mengembalikan clock sebelumnya
+func withClock(tb testing.TB, c Clock) {
+	tb.Helper()
+	previous := clock
+	clock = c
+	tb.Cleanup(func() { clock = previous })
+}
+
+func TestTrackingCodeFormat(t *testing.T) {
+	pattern := regexp.MustCompile(`^[` + trackingAlphabet + `]{3}-[` + trackingAlphabet + `]{3}$`)
+	g := NewTrackingCodeGenerator()
+	for i := 0; i < 100; i++ {
+		if code := g.Next(); !pattern.MatchString(code) {
+			t.Fatalf("kode %q tidak sesuai format", code)
+		}
+	}
+}
+
+func TestTrackingCodeDayUsesClock(t *testing.T) {
+	c := &fixedClock{t: time.Date(2024, 3, 1, 23, 59, 0, 0, time.UTC)}
+	withClock(t, c)
+
+	g := NewTrackingCodeGenerator()
+	g.Reserve("abc-def")
+	first := g.Next()
+	if !g.issued[first] {
+		t.Fatalf("kode %s tidak dicatat", first)
+	}
+
+	c.t = c.t.Add(2 * time.Minute)
+	g.Next()
+	if g.day != "2024-03-02" {
+		t.Errorf("hari = %s, ingin 2024-03-02", g.day)
+	}
+	if g.issued[first] {
+		t.Error("kode hari sebelumnya masih dicatat setelah hari berganti")
+	}
+	if !g.reserved["ABC-DEF"] {
+		t.Error("kode pesanan ditahan hilang setelah hari berganti")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// trainingSuffix disisipkan sebelum ekstensi file data pada mode latihan,
+// misalnya menu.json menjadi menu.latihan.json
+const trainingSuffix = ".latihan"
+
+// trainingPath path file data terpisah untuk mode latihan
+func trainingPath(path string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + trainingSuffix + ext
+}
+
+// applyTrainingMode mengalihkan konfigurasi ke data latihan: menu, nomor antrian,
+// pesanan ditahan dan catatan pembatalan memakai file terpisah, sedangkan voucher Wi-Fi, hook dan webhook stok dimatikan
+// karena efeknya keluar dari program. Salinan menu latihan dibuat dari menu asli
+// jika belum ada; hapus file tersebut untuk mengulang latihan dari awal.
+func applyTrainingMode(cfg *Config) error {
+	source := resolveMenuPath(cfg.MenuPath)
+	target := trainingPath(defaultMenuPath)
+	if source != "" {
+		target = trainingPath(source)
+	}
+
+	if _, err := os.Stat(target); errors.Is(err, os.ErrNotExist) {
+		data := defaultMenu
+		if source != "" {
+			if data, err = os.ReadFile(source); err != nil {
+				return fmt.Errorf("gagal membaca menu untuk latihan: %w", err)
+			}
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return fmt.Errorf("gagal membuat menu latihan: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("gagal membaca menu latihan: %w", err)
+	}
+	cfg.MenuPath = target
+
+	if cfg.QueueFile != "" {
+		cfg.QueueFile = trainingPath(cfg.QueueFile)
+	}
+	cfg.HeldOrdersPath = trainingPath(cfg.HeldOrdersPath)
+	cfg.CancelLogPath = trainingPath(cfg.CancelLogPath)
+	cfg.WifiVoucherPath = ""
+	cfg.HooksPath = ""
+	cfg.LowStockWebhook = ""
+	return nil
+}
+
+// printTrainingBanner menandai layar dan struk pada mode latihan
+func printTrainingBanner(w io.Writer) {
+	if plainOutput {
+		fmt.Fprintln(w, "Mode latihan. Transaksi ini bukan penjualan asli.")
+		return
+	}
+	fmt.Fprintln(w, "******** LATIHAN - BUKAN TRANSAKSI ASLI ********")
+}
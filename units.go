@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// parseQuantity mem-parsing jumlah item. Jumlah pecahan boleh memakai titik
+// atau koma sebagai pemisah desimal, misalnya "1,5".
+func parseQuantity(s string) (float64, error) {
+	q, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(s), ",", ".", 1), 64)
+	if err != nil || q <= 0 || math.IsInf(q, 0) {
+		return 0, fmt.Errorf("jumlah tidak valid: %s", s)
+	}
+	return q, nil
+}
+
+// validateQuantity memastikan jumlah pecahan hanya dipakai untuk item yang
+// dijual per satuan (misalnya per 100g atau per porsi)
+func validateQuantity(entry MenuEntry, quantity float64) error {
+	if entry.Unit == "" && quantity != math.Trunc(quantity) {
+		return fmt.Errorf("'%s' hanya bisa dipesan dalam jumlah bulat", entry.Name)
+	}
+	return nil
+}
+
+// formatQuantity memformat jumlah dengan pemisah desimal locale, misalnya "1,5"
+func formatQuantity(q float64) string {
+	return strings.Replace(strconv.FormatFloat(q, 'f', -1, 64), ".", currentLocale.DecimalSep, 1)
+}
+
+// describeQuantity memformat jumlah baris pesanan beserta satuannya, misalnya "2" atau "1,5 x 100g"
+func (m *MenuItem) describeQuantity() string {
+	if m.Unit == "" {
+		return formatQuantity(m.Quantity)
+	}
+	return formatQuantity(m.Quantity) + " x " + m.Unit
+}
+
+// quantityLabel label jumlah singkat untuk ringkasan, misalnya "x2" atau "1,5 x 100g"
+func (m *MenuItem) quantityLabel() string {
+	if m.Unit == "" {
+		return "x" + formatQuantity(m.Quantity)
+	}
+	return m.describeQuantity()
+}
+
+// perUnit akhiran harga untuk item bersatuan, misalnya " / 100g"
+func perUnit(entry MenuEntry, sep string) string {
+	if entry.Unit == "" {
+		return ""
+	}
+	return sep + entry.Unit
+}
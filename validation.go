@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnacknowledgedWarnings dikembalikan saat pesanan masih punya peringatan yang belum dikonfirmasi kasir
+var ErrUnacknowledgedWarnings = errors.New("peringatan pesanan belum dikonfirmasi kasir")
+
+// maxUsualQuantity batas jumlah per item yang dianggap wajar
+const maxUsualQuantity = 20
+
+// ValidationWarning peringatan pada pesanan yang tidak menolak pesanan,
+// tetapi harus dikonfirmasi kasir sebelum diproses
+type ValidationWarning struct {
+	Code    string
+	Message string
+}
+
+// validateOrder memeriksa pesanan. Error berarti pesanan ditolak,
+// sedangkan peringatan dikembalikan untuk dikonfirmasi kasir.
+func validateOrder(order *Order) ([]ValidationWarning, error) {
+	if len(order.Items) == 0 {
+		return nil, fmt.Errorf("pesanan tidak memiliki item")
+	}
+
+	var warnings []ValidationWarning
+	for _, item := range order.Items {
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("jumlah '%s' harus lebih dari nol", item.Name)
+		}
+		if item.Unit == "" && item.Quantity != math.Trunc(item.Quantity) {
+			return nil, fmt.Errorf("jumlah '%s' harus bilangan bulat", item.Name)
+		}
+		if item.Price < 0 {
+			return nil, fmt.Errorf("harga '%s' tidak boleh negatif", item.Name)
+		}
+		if item.Quantity > maxUsualQuantity {
+			warnings = append(warnings, ValidationWarning{
+				Code:    "jumlah_tidak_biasa",
+				Message: fmt.Sprintf("jumlah '%s' tidak biasa (%s)", item.Name, item.quantityLabel()),
+			})
+		}
+		if item.Price == 0 {
+			warnings = append(warnings, ValidationWarning{
+				Code:    "harga_nol",
+				Message: fmt.Sprintf("harga '%s' nol", item.Name),
+			})
+		}
+	}
+	return warnings, nil
+}
+
+// AcknowledgeWarnings menandai bahwa kasir sudah mengonfirmasi semua peringatan pesanan
+func (o *Order) AcknowledgeWarnings() {
+	o.WarningsAcknowledged = true
+}
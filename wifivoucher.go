@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// WifiVoucher satu kode voucher Wi-Fi tamu beserta status pemakaiannya
+type WifiVoucher struct {
+	Code     string     `json:"code"`
+	IssuedTo string     `json:"issued_to,omitempty"` // kode pesanan penerima voucher
+	IssuedAt *time.Time `json:"issued_at,omitempty"`
+}
+
+// WifiVoucherPool kumpulan voucher Wi-Fi sekali pakai yang disimpan di file JSON
+type WifiVoucherPool struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewWifiVoucherPool membuat pool voucher dengan data di path
+func NewWifiVoucherPool(path string) *WifiVoucherPool {
+	return &WifiVoucherPool{path: path}
+}
+
+// load membaca semua voucher dari file
+func (p *WifiVoucherPool) load() ([]WifiVoucher, error) {
+	b, err := os.ReadFile(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca voucher Wi-Fi: %w", err)
+	}
+	var vouchers []WifiVoucher
+	if err := json.Unmarshal(b, &vouchers); err != nil {
+		return nil, fmt.Errorf("format voucher Wi-Fi tidak valid: %w", err)
+	}
+	return vouchers, nil
+}
+
+// Issue mengambil voucher pertama yang belum dipakai, mencatat penerimanya, lalu menyimpan pool
+func (p *WifiVoucherPool) Issue(order *Order) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	vouchers, err := p.load()
+	if err != nil {
+		return "", err
+	}
+	for i := range vouchers {
+		if vouchers[i].IssuedTo != "" {
+			continue
+		}
+		vouchers[i].IssuedTo = order.TrackingCode
+		now := clock.Now()
+		vouchers[i].IssuedAt = &now
+
+		b, err := json.MarshalIndent(vouchers, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(p.path, append(b, '\n'), 0o644); err != nil {
+			return "", fmt.Errorf("gagal menyimpan voucher Wi-Fi: %w", err)
+		}
+		return vouchers[i].Code, nil
+	}
+	return "", fmt.Errorf("voucher Wi-Fi habis")
+}
+
+// Status mengembalikan jumlah voucher yang tersisa dan yang sudah diberikan
+func (p *WifiVoucherPool) Status() (available, issued int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	vouchers, err := p.load()
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, v := range vouchers {
+		if v.IssuedTo != "" {
+			issued++
+		} else {
+			available++
+		}
+	}
+	return available, issued, nil
+}